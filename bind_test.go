@@ -73,3 +73,50 @@ func (s *bindSuite) Test_Json(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
 }
+
+func (s *bindSuite) Test_JsonStructuredSuffix(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, `application/vnd.api+json`)
+	err := Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+}
+
+func (s *bindSuite) Test_Xml(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `<Post><Title>Glorious Post Title</Title></Post>`, `text/xml; charset=utf-8`)
+	err := Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+}
+
+func (s *bindSuite) Test_XmlStructuredSuffix(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `<Post><Content>Lorem ipsum</Content></Post>`, `application/atom+xml`)
+	err := Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Content: "Lorem ipsum"})
+}
+
+func (s *bindSuite) Test_JsonIsNotMatchedBySubstring(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, `application/jsonp`)
+	err := Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}
+
+func (s *bindSuite) Test_Default(c *C) {
+	c.Assert(Default(`POST`, `application/json; charset=utf-8`), Equals, JSON)
+	c.Assert(Default(`POST`, `Application/JSON`), Equals, JSON)
+	c.Assert(Default(`POST`, `application/problem+json`), Equals, JSON)
+	c.Assert(Default(`PUT`, `application/xml`), Equals, XML)
+	c.Assert(Default(`PUT`, `application/atom+xml; charset=utf-8`), Equals, XML)
+	c.Assert(Default(`POST`, `multipart/form-data; boundary=abc`), Equals, MultipartForm)
+	c.Assert(Default(`POST`, `application/x-www-form-urlencoded`), Equals, Form)
+	c.Assert(Default(`POST`, `BoGuS`), Equals, Form)
+	c.Assert(Default(`GET`, ``), Equals, Form)
+}
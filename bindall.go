@@ -0,0 +1,246 @@
+package binding
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The sources of BindAll, from the lowest to the highest precedence. The
+// body binding is chosen by Default and comes first.
+var bindAllSources = []fieldBinder{cookieBinding{}, headerBinding{}, queryBinding{}, pathBinding{}}
+
+// The tags of the body bindings from outside this package, which do not
+// tell theirs.
+var bindAllBodyTags = []string{"form", "json", "xml"}
+
+// BindAll fills dst from the request body and the path, query, header and
+// cookie values of the request at once. Each field selects its sources by
+// tag, like path:"id", query:"page", header:"X-Request-Id", cookie:"session"
+// or the tags of the body binding, which is chosen by Default for the
+// method and content type of the request.
+//
+// When a field is tagged for more than one source, the value of the source
+// with the highest precedence is kept: path, query, header, cookie and
+// finally the body. Sources that provide different values for the same
+// field are reported as ConflictError field errors. The struct is validated
+// once all sources are bound.
+func BindAll(dst interface{}, req *http.Request) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
+	}
+
+	// The query string is a source of its own, so form fields are only
+	// bound from the body.
+	body := Default(req.Method, req.Header.Get("Content-Type"))
+	if body == Form {
+		body = FormPost
+	}
+	bodyTags := bindAllBodyTags
+	if binder, ok := body.(fieldBinder); ok {
+		bodyTags = binder.tags()
+	}
+
+	errs := Errors{}
+	tracker := newSourceTracker(v, bodyTags)
+	if err := bindSource(body, dst, req, &errs); err != nil {
+		return err
+	}
+	tracker.update(v, &errs, "body")
+
+	for _, source := range bindAllSources {
+		if err := source.bind(dst, req, &errs); err != nil {
+			return err
+		}
+		tracker.update(v, &errs, source.Name())
+	}
+
+	validate(&errs, reflect.ValueOf(dst), req, tracker.tags...)
+	return errs.errorOrNil()
+}
+
+// Binds the request with b without validating it. Bindings from outside
+// this package can only be bound and validated at once.
+func bindSource(b Binding, dst interface{}, req *http.Request, errs *Errors) error {
+	if binder, ok := b.(fieldBinder); ok {
+		return binder.bind(dst, req, errs)
+	}
+
+	err := b.Bind(dst, req)
+	if fieldErrs, ok := err.(Errors); ok {
+		*errs = append(*errs, fieldErrs...)
+		return nil
+	}
+	return err
+}
+
+// Tracks the fields that are tagged for more than one source of BindAll by
+// their path, like author.name, to report the sources that conflict.
+type sourceTracker struct {
+	body     []string
+	tags     []string
+	previous map[string]reflect.Value
+	setBy    map[string]string
+}
+
+// A copy of the value of a tracked field.
+type trackedValue struct {
+	path  string
+	value reflect.Value
+}
+
+func newSourceTracker(v reflect.Value, body []string) *sourceTracker {
+	t := &sourceTracker{body: body, previous: map[string]reflect.Value{}, setBy: map[string]string{}}
+	// The fields are named by their source with the highest precedence.
+	for i := len(bindAllSources) - 1; i >= 0; i-- {
+		t.tags = append(t.tags, bindAllSources[i].tags()...)
+	}
+	t.tags = append(t.tags, body...)
+
+	for _, tracked := range t.collect(v, "", nil) {
+		t.previous[tracked.path] = tracked.value
+	}
+	return t
+}
+
+// Records the fields changed by source, reporting a conflict when another
+// source already set the field to a different value.
+func (t *sourceTracker) update(v reflect.Value, errs *Errors, source string) {
+	current := t.collect(v, "", nil)
+	previous := make(map[string]reflect.Value, len(current))
+	for _, tracked := range current {
+		previous[tracked.path] = tracked.value
+
+		value, ok := t.previous[tracked.path]
+		if !ok {
+			value = reflect.Zero(tracked.value.Type())
+		}
+		if reflect.DeepEqual(value.Interface(), tracked.value.Interface()) {
+			continue
+		}
+		if by := t.setBy[tracked.path]; by != "" && !errs.hasField(tracked.path) {
+			errs.Add(tracked.path, ConflictError, fmt.Sprintf("conflicting values from %s and %s", by, source))
+		}
+		t.setBy[tracked.path] = source
+	}
+	t.previous = previous
+}
+
+// Collects the fields of v that are tagged for more than one source,
+// including those of embedded, nested, referenced and repeated structs.
+func (t *sourceTracker) collect(v reflect.Value, prefix string, tracked []trackedValue) []trackedValue {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := v.Field(i)
+		if !structField.CanSet() {
+			continue
+		}
+
+		nested := isStructOrSliceOfStructs(typeField.Type) &&
+			!isTimeType(typeField.Type) && !isUnmarshalerType(typeField.Type)
+		if !nested && t.sources(typeField) > 1 {
+			tracked = append(tracked, trackedValue{path: prefix + fieldName(typeField, t.tags...), value: deepCopy(structField)})
+			continue
+		}
+
+		path := prefix
+		if !typeField.Anonymous {
+			path += fieldName(typeField, t.tags...) + "."
+		}
+		tracked = t.collectNested(structField, path, tracked)
+	}
+	return tracked
+}
+
+func (t *sourceTracker) collectNested(v reflect.Value, prefix string, tracked []trackedValue) []trackedValue {
+	if isTimeType(v.Type()) || isUnmarshalerType(v.Type()) {
+		return tracked
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			tracked = t.collectNested(v.Elem(), prefix, tracked)
+		}
+	case reflect.Struct:
+		tracked = t.collect(v, prefix, tracked)
+	case reflect.Slice:
+		if elem := v.Type().Elem().Kind(); elem != reflect.Struct && elem != reflect.Ptr {
+			break
+		}
+		for i := 0; i < v.Len(); i++ {
+			tracked = t.collectNested(v.Index(i), prefix+strconv.Itoa(i)+".", tracked)
+		}
+	}
+	return tracked
+}
+
+// Returns a copy of v that shares no maps, slices or pointers with it, as
+// the sources change those in place.
+func deepCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		c.Set(reflect.New(v.Type().Elem()))
+		c.Elem().Set(deepCopy(v.Elem()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Struct:
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	default:
+		c.Set(v)
+	}
+	return c
+}
+
+// Returns the number of sources field is tagged for, counting the tags of
+// the body as one.
+func (t *sourceTracker) sources(field reflect.StructField) int {
+	sources := 0
+	for _, source := range bindAllSources {
+		if hasTag(field, source.tags()[0]) {
+			sources++
+		}
+	}
+	for _, tag := range t.body {
+		if hasTag(field, tag) {
+			return sources + 1
+		}
+	}
+	return sources
+}
+
+func hasTag(field reflect.StructField, tag string) bool {
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	return name != "" && name != "-"
+}
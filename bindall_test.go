@@ -0,0 +1,268 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	UpdateUser struct {
+		Id        int    `path:"id" json:"id"`
+		Version   int    `query:"version" validate:"Required"`
+		RequestId string `header:"X-Request-Id"`
+		Session   string `cookie:"session"`
+		Name      string `json:"name" validate:"Required"`
+		Email     string `json:"email"`
+	}
+
+	SearchUsers struct {
+		Query string `form:"q"`
+		Page  int    `query:"page" header:"X-Page"`
+	}
+
+	ListOrders struct {
+		Filter OrderFilter `json:"filter" query:"filter"`
+	}
+
+	OrderFilter struct {
+		Status string `json:"status" query:"status"`
+	}
+
+	ArticleMeta struct {
+		Name string `json:"name"`
+	}
+
+	EditArticle struct {
+		*ArticleMeta
+		Id int `path:"id"`
+	}
+
+	TagArticle struct {
+		Id    int               `path:"id"`
+		Attrs map[string]string `json:"attrs"`
+		Items []ArticleMeta     `json:"items"`
+		Tags  map[string]string `json:"tags" query:"tags"`
+	}
+
+	RenameAccount struct {
+		Id   int    `path:"id" yaml:"account_id"`
+		Name string `yaml:"display_name" validate:"Required"`
+	}
+)
+
+type bindAllSuite struct{}
+
+var _ = Suite(&bindAllSuite{})
+
+func (s *bindAllSuite) Test_NotByReference(c *C) {
+	user := UpdateUser{}
+	err := BindAll(user, newBindAllRequest("PUT", "/users/1", MIMEJSON, `{}`))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *bindAllSuite) Test_AllSources(c *C) {
+	req := newBindAllRequest("PUT", "/users/42?version=3", MIMEJSON, `{"name":"Ada","email":"ada@example.com"}`)
+	req.SetPathValue("id", "42")
+	req.Header.Set("X-Request-Id", "abc-123")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+
+	user := UpdateUser{}
+	err := BindAll(&user, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(user, DeepEquals, UpdateUser{
+		Id:        42,
+		Version:   3,
+		RequestId: "abc-123",
+		Session:   "s3cr3t",
+		Name:      "Ada",
+		Email:     "ada@example.com",
+	})
+}
+
+func (s *bindAllSuite) Test_FormBody(c *C) {
+	req := newBindAllRequest("POST", "/users?q=grace", MIMEPOSTForm, "q=ada")
+	req.Header.Set("X-Page", "2")
+
+	search := SearchUsers{}
+	err := BindAll(&search, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(search, DeepEquals, SearchUsers{Query: "ada", Page: 2})
+}
+
+func (s *bindAllSuite) Test_FormFieldsIgnoreQuery(c *C) {
+	req := newBindAllRequest("GET", "/users?q=ada&page=2", "", "")
+
+	search := SearchUsers{}
+	err := BindAll(&search, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(search, DeepEquals, SearchUsers{Page: 2})
+}
+
+func (s *bindAllSuite) Test_Precedence(c *C) {
+	req := newBindAllRequest("PUT", "/users/42?version=1", MIMEJSON, `{"name":"Ada"}`)
+	req.SetPathValue("id", "42")
+
+	user := UpdateUser{Id: 7}
+	err := BindAll(&user, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(user.Id, Equals, 42)
+}
+
+func (s *bindAllSuite) Test_SameValueIsNoConflict(c *C) {
+	req := newBindAllRequest("PUT", "/users/42?version=1", MIMEJSON, `{"id":42,"name":"Ada"}`)
+	req.SetPathValue("id", "42")
+
+	user := UpdateUser{}
+	err := BindAll(&user, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(user.Id, Equals, 42)
+}
+
+func (s *bindAllSuite) Test_Conflict(c *C) {
+	req := newBindAllRequest("PUT", "/users/42?version=1", MIMEJSON, `{"id":7,"name":"Ada"}`)
+	req.SetPathValue("id", "42")
+
+	user := UpdateUser{}
+	err := BindAll(&user, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "id")
+	c.Assert(errs[0].Classification, Equals, ConflictError)
+	c.Assert(errs[0].Message, Equals, "conflicting values from body and path")
+	c.Assert(errors.Is(err, ErrorValidation), Equals, true)
+	c.Assert(user.Id, Equals, 42)
+}
+
+func (s *bindAllSuite) Test_ConflictBetweenQueryAndHeader(c *C) {
+	req := newBindAllRequest("GET", "/users?page=1", "", "")
+	req.Header.Set("X-Page", "2")
+
+	search := SearchUsers{}
+	err := BindAll(&search, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "page")
+	c.Assert(errs[0].Classification, Equals, ConflictError)
+	c.Assert(search.Page, Equals, 1)
+}
+
+func (s *bindAllSuite) Test_NestedConflict(c *C) {
+	req := newBindAllRequest("POST", "/orders?filter.status=open", MIMEJSON, `{"filter":{"status":"closed"}}`)
+
+	orders := ListOrders{}
+	err := BindAll(&orders, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "filter.status")
+	c.Assert(errs[0].Classification, Equals, ConflictError)
+	c.Assert(errs[0].Message, Equals, "conflicting values from body and query")
+	c.Assert(orders.Filter.Status, Equals, "open")
+}
+
+func (s *bindAllSuite) Test_BodyBindingTags(c *C) {
+	req := newBindAllRequest("PUT", "/accounts/42", MIMEYAML, "account_id: 7\n")
+	req.SetPathValue("id", "42")
+
+	account := RenameAccount{}
+	err := BindAll(&account, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "id")
+	c.Assert(errs[0].Classification, Equals, ConflictError)
+	c.Assert(errs[1].Field, Equals, "display_name")
+	c.Assert(errs[1].Classification, Equals, RequiredError)
+}
+
+func (s *bindAllSuite) Test_KeepsEmbeddedPointerOfBody(c *C) {
+	req := newBindAllRequest("PUT", "/articles/42", MIMEJSON, `{"name":"kept"}`)
+	req.SetPathValue("id", "42")
+
+	article := EditArticle{}
+	err := BindAll(&article, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(article, DeepEquals, EditArticle{ArticleMeta: &ArticleMeta{Name: "kept"}, Id: 42})
+}
+
+func (s *bindAllSuite) Test_QueryLeavesBodyFields(c *C) {
+	req := newBindAllRequest("PUT", "/articles/42?attrs.admin=true&items.5.name=x", MIMEJSON, `{"attrs":{"role":"editor"},"items":[{"name":"a"}]}`)
+	req.SetPathValue("id", "42")
+
+	article := TagArticle{}
+	err := BindAll(&article, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(article, DeepEquals, TagArticle{
+		Id:    42,
+		Attrs: map[string]string{"role": "editor"},
+		Items: []ArticleMeta{{Name: "a"}},
+	})
+}
+
+func (s *bindAllSuite) Test_MapConflict(c *C) {
+	req := newBindAllRequest("PUT", "/articles/42?tags.a=2", MIMEJSON, `{"tags":{"a":"1"}}`)
+	req.SetPathValue("id", "42")
+
+	article := TagArticle{}
+	err := BindAll(&article, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "tags")
+	c.Assert(errs[0].Classification, Equals, ConflictError)
+	c.Assert(errs[0].Message, Equals, "conflicting values from body and query")
+	c.Assert(article.Tags, DeepEquals, map[string]string{"a": "2"})
+}
+
+func (s *bindAllSuite) Test_Validation(c *C) {
+	req := newBindAllRequest("PUT", "/users/42", MIMEJSON, `{"email":"ada@example.com"}`)
+
+	user := UpdateUser{}
+	err := BindAll(&user, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "version")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+	c.Assert(errs[1].Field, Equals, "name")
+	c.Assert(errs[1].Classification, Equals, RequiredError)
+}
+
+func (s *bindAllSuite) Test_BodyError(c *C) {
+	req := newBindAllRequest("PUT", "/users/42", MIMEJSON, `{"name":`)
+
+	user := UpdateUser{}
+	err := BindAll(&user, req)
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func newBindAllRequest(method, target, contentType, body string) *http.Request {
+	req, err := http.NewRequest(method, target, strings.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
@@ -1,12 +1,18 @@
 package binding
 
 import (
+	"encoding"
 	"errors"
+	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -19,6 +25,18 @@ const (
 	MIMEPlain     = "text/plain"
 	MIMEPOSTForm  = "application/x-www-form-urlencoded"
 	MIMEMultipart = "multipart/form-data"
+	MIMEYAML      = "application/yaml"
+	MIMEYAML2     = "application/x-yaml"
+	MIMEYAML3     = "text/yaml"
+	MIMEMsgPack   = "application/msgpack"
+	MIMEMsgPack2  = "application/x-msgpack"
+	MIMEProtoBuf  = "application/x-protobuf"
+	MIMEProtoBuf2 = "application/protobuf"
+	MIMECBOR      = "application/cbor"
+	MIMETOML      = "application/toml"
+	MIMECSV       = "text/csv"
+	MIMENDJSON    = "application/x-ndjson"
+	MIMEJSONL     = "application/jsonl"
 )
 
 type Binding interface {
@@ -26,240 +44,132 @@ type Binding interface {
 	Bind(interface{}, *http.Request) error
 }
 
+// Implemented by the bindings of this package. Bind maps the request
+// into dst without validating it, collecting field errors in errs, so
+// multiple bindings can be combined before the result is validated.
+type fieldBinder interface {
+	Binding
+	bind(dst interface{}, req *http.Request, errs *Errors) error
+
+	// The struct tags naming the fields, of which the first present is used
+	tags() []string
+}
+
+// Binds the request with b and validates the result, naming the fields in
+// the errors by the tags of b.
+func bindValidated(b fieldBinder, dst interface{}, req *http.Request) error {
+	errs := Errors{}
+	if err := b.bind(dst, req, &errs); err != nil {
+		return err
+	}
+	validate(&errs, reflect.ValueOf(dst), req, b.tags()...)
+	return errs.errorOrNil()
+}
+
+// Binds the request with b and validates only the fields with the tag of
+// b, for the bindings that fill part of a struct from a single source.
+func bindValidatedSource(b fieldBinder, dst interface{}, req *http.Request) error {
+	errs := Errors{}
+	if err := b.bind(dst, req, &errs); err != nil {
+		return err
+	}
+	validateSource(&errs, reflect.ValueOf(dst), b.tags()[0])
+	return errs.errorOrNil()
+}
+
+// FormUnmarshaler is implemented by types that decode themselves from the
+// form values posted for their field.
+type FormUnmarshaler interface {
+	UnmarshalForm(values []string) error
+}
+
 var (
 	// Maximum amount of memory to use when parsing a multipart form.
 	// Set this to whatever value you prefer; default is 16 MB.
 	MaxMemory = int64(1024 * 1024 * 16)
 
+	// StrictConversion makes form values that cannot be converted to the
+	// type of their field (like "asdf" for an int or 300 for an int8) fail
+	// the binding with TypeError field errors. When disabled those fields
+	// are left at their zero value and the binding succeeds; the failures
+	// are then passed to OnConversionError instead.
+	StrictConversion = false
+
+	// OnConversionError receives every conversion failure that is not fatal
+	// because StrictConversion is disabled, with the field path and the
+	// original input, e.g. to log them. Leave it nil to ignore them.
+	OnConversionError func(*FieldError)
+
+	// MaxSliceIndex is the highest index accepted in indexed form keys like
+	// reviewers.1.name, and MaxSliceLength the maximum number of elements a
+	// slice is sized to. Keys beyond these limits are reported as IndexError
+	// field errors instead of allocating huge slices.
+	MaxSliceIndex  = 10000
+	MaxSliceLength = 1000
+
+	// CompactSliceIndices lays out sparse indices consecutively, so the
+	// indices 1, 5 and 9 fill a slice of three elements instead of leaving
+	// zero value holes between them.
+	CompactSliceIndices = false
+
+	// MaxBodySize is the maximum number of bytes read from the body of a
+	// JSON, XML, YAML, MessagePack, Protocol Buffers, TOML, CBOR, CSV or
+	// NDJSON request; default is 16 MB. Larger bodies fail the binding with a
+	// DeserializationError. Set it to 0 to accept bodies of any size. Form
+	// and multipart bodies are limited by ParseForm and MaxMemory instead.
+	MaxBodySize = int64(1024 * 1024 * 16)
+
+	// MaxLineSize is the maximum number of bytes of a line of an NDJSON
+	// body; default is 1 MB. A longer line fails the binding with a
+	// DeserializationError for its record.
+	MaxLineSize = 1024 * 1024
+
+	// MaxRecordErrors is the maximum number of NDJSON records that may fail
+	// before the binding stops reading the body; default is 100.
+	MaxRecordErrors = 100
+
 	ErrorDeserialization        = errors.New("Deserialization error")
 	ErrorEmptyContentType       = errors.New("Empty Content-Type")
 	ErrorUnsupportedContentType = errors.New("Unsupported Content-Type")
 	ErrorInputNotByReference    = errors.New("input binding model is not by reference")
 	ErrorInputIsNotStructure    = errors.New("binding model is required to be structure")
+	ErrorInputIsNotProtoMessage = errors.New("binding model is required to be a proto.Message")
+	ErrorInputIsNotSlice        = errors.New("binding model is required to be a slice of structures")
+	ErrorInputIsNotStream       = errors.New("binding model is required to be a callback func or channel")
+	ErrorValidation             = errors.New("Validation error")
 
 	JSON          = jsonBinding{}
 	XML           = xmlBinding{}
+	YAML          = yamlBinding{}
+	MsgPack       = msgpackBinding{}
+	ProtoBuf      = protobufBinding{}
+	CBOR          = cborBinding{}
+	TOML          = tomlBinding{}
+	CSV           = csvBinding{}
+	NDJSON        = ndjsonBinding{}
 	Form          = formBinding{}
+	FormPost      = formPostBinding{}
 	MultipartForm = multipartBinding{}
+	Query         = queryBinding{}
+	Path          = pathBinding{}
+	Header        = headerBinding{}
+	Cookie        = cookieBinding{}
 )
 
+// Default returns the binding registered in DefaultRegistry for the method
+// and content type of a request, falling back to the binding registered for
+// form-urlencoded payloads when the content type is not supported.
 func Default(method, contentType string) Binding {
-	if method == "POST" || method == "PUT" || method == "PATCH" || contentType != "" {
-		switch contentType {
-		case MIMEMultipart:
-			return MultipartForm
-		case MIMEPOSTForm:
-			return Form
-		case MIMEJSON:
-			return JSON
-		case MIMEXML, MIMEXML2:
-			return XML
-		default:
-			/*if contentType == "" {
-				return Errors{ErrorEmptyContentType}
-			} else {
-				return Errors{ErrorUnsupportedContentType}
-			}*/
-			return Form
-		}
-	}
-	return Form
+	return DefaultRegistry.Default(method, contentType)
 }
 
+// Bind deserializes the request into obj with the binding registered in
+// DefaultRegistry for its method and content type. Requests with a body
+// must have a supported content type.
 func Bind(obj interface{}, req *http.Request) error {
-	contentType := req.Header.Get("Content-Type")
-	if req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH" || contentType != "" {
-		if strings.Contains(contentType, "form-urlencoded") {
-			return Form.Bind(obj, req)
-		} else if strings.Contains(contentType, "multipart/form-data") {
-			return MultipartForm.Bind(obj, req)
-		} else if strings.Contains(contentType, "json") {
-			return JSON.Bind(obj, req)
-		} else {
-			if contentType == "" {
-				return ErrorEmptyContentType
-			} else {
-				return ErrorUnsupportedContentType
-			}
-		}
-	} else {
-		return Form.Bind(obj, req)
-	}
+	return DefaultRegistry.Bind(obj, req)
 }
 
-/*
-var (
-	alphaDashPattern    = regexp.MustCompile("[^\\d\\w-_]")
-	alphaDashDotPattern = regexp.MustCompile("[^\\d\\w-_\\.]")
-	emailPattern        = regexp.MustCompile("[\\w!#$%&'*+/=?^_`{|}~-]+(?:\\.[\\w!#$%&'*+/=?^_`{|}~-]+)*@(?:[\\w](?:[\\w-]*[\\w])?\\.)+[a-zA-Z0-9](?:[\\w-]*[\\w])?")
-	urlPattern          = regexp.MustCompile(`(http|https):\/\/[\w\-_]+(\.[\w\-_]+)+([\w\-\.,@?^=%&amp;:/~\+#]*[\w\-\@?^=%&amp;/~\+#])?`)
-)
-*/
-/*
-// Performs required field checking on a struct
-func validateStruct(errors Errors, obj interface{}, path string) Errors {
-	typ := reflect.TypeOf(obj)
-	val := reflect.ValueOf(obj)
-
-	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
-		val = val.Elem()
-	}
-
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-
-		// Allow ignored fields in the struct
-		if field.Tag.Get("form") == "-" || !val.Field(i).CanInterface() {
-			continue
-		}
-
-		fieldVal := val.Field(i)
-		fieldValue := fieldVal.Interface()
-		zero := reflect.Zero(field.Type).Interface()
-
-		// Validate nested and embedded structs (if pointer, only do so if not nil)
-		if field.Type.Kind() == reflect.Struct ||
-			(field.Type.Kind() == reflect.Ptr && !reflect.DeepEqual(zero, fieldValue) &&
-				field.Type.Elem().Kind() == reflect.Struct) {
-			fieldPath := path
-			if field.Anonymous == false {
-				fieldPath = path + field.Name + "."
-			}
-			errors = validateStruct(errors, fieldValue, fieldPath)
-			// Validate structure slices
-		} else if field.Type.Kind() == reflect.Slice &&
-			(field.Type.Elem().Kind() == reflect.Struct ||
-				(field.Type.Elem().Kind() == reflect.Ptr && field.Type.Elem().Elem().Kind() == reflect.Struct)) {
-			for i := 0; i < fieldVal.Len(); i++ {
-				fieldPath := path + field.Name + "." + strconv.Itoa(i) + "."
-				errors = validateStruct(errors, fieldVal.Index(i).Interface(), fieldPath)
-			}
-		}
-
-		// Match rules.
-	VALIDATE_RULES:
-		for _, rule := range strings.Split(field.Tag.Get("validate"), ";") {
-			if len(rule) == 0 {
-				continue
-			}
-
-			switch {
-			case rule == "Required":
-				if reflect.DeepEqual(zero, fieldValue) {
-					errors.Add([]string{path + field.Name}, RequiredError, "Required")
-					break
-				}
-			case rule == "AlphaDash":
-				if alphaDashPattern.MatchString(fmt.Sprintf("%v", fieldValue)) {
-					errors.Add([]string{path + field.Name}, AlphaDashError, "AlphaDash")
-					break VALIDATE_RULES
-				}
-			case rule == "AlphaDashDot":
-				if alphaDashDotPattern.MatchString(fmt.Sprintf("%v", fieldValue)) {
-					errors.Add([]string{path + field.Name}, AlphaDashDotError, "AlphaDashDot")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "MinSize("):
-				min, _ := strconv.Atoi(rule[8 : len(rule)-1])
-				if str, ok := fieldValue.(string); ok && utf8.RuneCountInString(str) < min {
-					errors.Add([]string{path + field.Name}, MinSizeError, "MinSize")
-					break VALIDATE_RULES
-				}
-				v := reflect.ValueOf(fieldValue)
-				if v.Kind() == reflect.Slice && v.Len() < min {
-					errors.Add([]string{path + field.Name}, MinSizeError, "MinSize")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "MaxSize("):
-				max, _ := strconv.Atoi(rule[8 : len(rule)-1])
-				if str, ok := fieldValue.(string); ok && utf8.RuneCountInString(str) > max {
-					errors.Add([]string{path + field.Name}, MaxSizeError, "MaxSize")
-					break VALIDATE_RULES
-				}
-				v := reflect.ValueOf(fieldValue)
-				if v.Kind() == reflect.Slice && v.Len() > max {
-					errors.Add([]string{path + field.Name}, MaxSizeError, "MaxSize")
-					break VALIDATE_RULES
-				}
-			case rule == "Email":
-				if !emailPattern.MatchString(fmt.Sprintf("%v", fieldValue)) {
-					errors.Add([]string{path + field.Name}, EmailError, "Email")
-					break VALIDATE_RULES
-				}
-			case rule == "Url":
-				str := fmt.Sprintf("%v", fieldValue)
-				if len(str) == 0 {
-					continue
-				} else if !urlPattern.MatchString(str) {
-					errors.Add([]string{path + field.Name}, UrlError, "Url")
-					break VALIDATE_RULES
-				}
-
-			// TODO write test for these validation rules
-			case strings.HasPrefix(rule, "Range("):
-				nums := strings.Split(rule[6:len(rule)-1], ",")
-				if len(nums) != 2 {
-					break
-				}
-				val, _ := strconv.ParseInt(fmt.Sprintf("%v", fieldValue), 10, 32)
-				a, _ := strconv.ParseInt(nums[0], 10, 32)
-				b, _ := strconv.ParseInt(nums[1], 10, 32)
-				if val < a || val > b {
-					errors.Add([]string{path + field.Name}, RangeError, "Range")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "In("):
-				if !in(fieldValue, rule[3:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, InError, "In")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "NotIn("):
-				if in(fieldValue, rule[6:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, NotInError, "NotIn")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "Include("):
-				if !strings.Contains(fmt.Sprintf("%v", fieldValue), rule[8:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, IncludeError, "Include")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "Exclude("):
-				if strings.Contains(fmt.Sprintf("%v", fieldValue), rule[8:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, ExcludeError, "Exclude")
-					break
-				}
-			case strings.HasPrefix(rule, "Default("):
-				if reflect.DeepEqual(zero, fieldValue) {
-					if fieldVal.CanAddr() {
-						setWithProperType(field.Type.Kind(), rule[8:len(rule)-1], fieldVal, field.Tag.Get("form"), errors)
-					} else {
-						errors.Add([]string{path + field.Name}, DefaultError, "Default")
-						break VALIDATE_RULES
-					}
-				}
-
-			}
-		}
-	}
-	return errors
-}
-*/
-//validation in function
-/*
-func in(fieldValue interface{}, arr string) bool {
-	val := fmt.Sprintf("%v", fieldValue)
-	vals := strings.Split(arr, ",")
-	isIn := false
-	for _, v := range vals {
-		if v == val {
-			isIn = true
-			break
-		}
-	}
-	return isIn
-}
-*/
 /*
 func mapFormValues(field string, form map[string][]string) (result []map[string][]string) {
 	for key, values := range form {
@@ -285,57 +195,437 @@ func mapFormValues(field string, form map[string][]string) (result []map[string]
 	return result
 }*/
 
-func pathSliceSize(field string, form map[string][]string) int {
-	size := 0
-	for key, _ := range form {
-		parts := strings.SplitN(key, ".", 3)
-		if len(parts) == 3 && parts[0] == field {
-			index, err := strconv.Atoi(parts[1])
-			if err != nil {
-				continue
+// Returns the body of the request, limited to MaxBodySize bytes.
+func limitedBody(req *http.Request) io.Reader {
+	if MaxBodySize <= 0 {
+		return req.Body
+	}
+	return http.MaxBytesReader(nil, req.Body, MaxBodySize)
+}
+
+// Returns the struct dst points to, allocating it when dst is a pointer to a
+// nil struct pointer.
+func structOf(dst interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return v, ErrorInputNotByReference
+	}
+
+	//reset element to zero variant
+	v = v.Elem()
+	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return v, ErrorInputIsNotStructure
+	}
+	return v, nil
+}
+
+// Holds the values of a form together with an index of its keys, built once
+// per request, so nested structs and slices can be resolved without scanning
+// all the keys of the form. Tag is the struct tag that names the fields in
+// the form, like form for request bodies or path for path parameters.
+type formData struct {
+	tag      string
+	values   map[string][]string
+	files    map[string][]*multipart.FileHeader
+	prefixes map[string]bool
+	indices  map[string]map[int]bool
+	children map[string]map[string]bool
+}
+
+func newFormData(tag string, values map[string][]string, files map[string][]*multipart.FileHeader) *formData {
+	data := &formData{
+		tag:      tag,
+		values:   normalizeKeys(values),
+		files:    normalizeFileKeys(files),
+		prefixes: make(map[string]bool),
+		indices:  make(map[string]map[int]bool),
+		children: make(map[string]map[string]bool),
+	}
+	for key := range data.values {
+		data.indexKey(key)
+	}
+	for key := range data.files {
+		data.indexKey(key)
+	}
+	return data
+}
+
+// Rewrites keys in bracket notation, as sent by PHP, Rails and jQuery
+// clients, to the dotted notation used by mapForm. Values of keys that end
+// up the same are merged. Returns values unchanged when there are none.
+func normalizeKeys(values map[string][]string) map[string][]string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	bracketKeys := sortedBracketKeys(keys)
+	if len(bracketKeys) == 0 {
+		return values
+	}
+
+	normalized := make(map[string][]string, len(values))
+	for key, value := range values {
+		normalized[key] = value
+	}
+	for _, key := range bracketKeys {
+		value := normalized[key]
+		delete(normalized, key)
+		dotted := dottedKey(key)
+		normalized[dotted] = append(append([]string(nil), normalized[dotted]...), value...)
+	}
+	return normalized
+}
+
+// The file variant of normalizeKeys
+func normalizeFileKeys(files map[string][]*multipart.FileHeader) map[string][]*multipart.FileHeader {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	bracketKeys := sortedBracketKeys(keys)
+	if len(bracketKeys) == 0 {
+		return files
+	}
+
+	normalized := make(map[string][]*multipart.FileHeader, len(files))
+	for key, value := range files {
+		normalized[key] = value
+	}
+	for _, key := range bracketKeys {
+		value := normalized[key]
+		delete(normalized, key)
+		dotted := dottedKey(key)
+		normalized[dotted] = append(append([]*multipart.FileHeader(nil), normalized[dotted]...), value...)
+	}
+	return normalized
+}
+
+// Returns the keys in bracket notation in a stable order
+func sortedBracketKeys(keys []string) []string {
+	bracketKeys := []string{}
+	for _, key := range keys {
+		if dottedKey(key) != key {
+			bracketKeys = append(bracketKeys, key)
+		}
+	}
+	sort.Strings(bracketKeys)
+	return bracketKeys
+}
+
+// Converts a key like reviewers[1][name] to reviewers.1.name and tags[] to
+// tags. Keys with unbalanced or empty brackets halfway are kept as is.
+func dottedKey(key string) string {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 {
+		return key
+	}
+
+	dotted := []byte(key[:open])
+	for i := open; i < len(key); {
+		switch key[i] {
+		case '[':
+			end := strings.IndexByte(key[i:], ']')
+			if end == -1 {
+				return key
 			}
+			segment := key[i+1 : i+end]
+			if segment == "" {
+				if i+end != len(key)-1 {
+					return key
+				}
+			} else {
+				dotted = append(dotted, '.')
+				dotted = append(dotted, segment...)
+			}
+			i += end + 1
+		default:
+			dotted = append(dotted, key[i])
+			i++
+		}
+	}
+	return string(dotted)
+}
+
+// Registers all parent paths of the key and the segment that follows them,
+// and for indexed paths like reviewers.1.name or tags.0 the index posted for
+// the slice at reviewers or tags.
+func (d *formData) indexKey(key string) {
+	for i := 0; i < len(key); i++ {
+		if key[i] != '.' {
+			continue
+		}
+		parent := key[:i]
+
+		end := strings.IndexByte(key[i+1:], '.')
+		child := key[i+1:]
+		if end != -1 {
+			child = key[i+1 : i+1+end]
+		}
+		if d.children[parent] == nil {
+			d.children[parent] = make(map[string]bool)
+		}
+		d.children[parent][child] = true
+		d.prefixes[parent] = true
 
-			if size < index+1 {
-				size = index + 1
+		if index, err := strconv.Atoi(child); err == nil && index >= 0 {
+			if d.indices[parent] == nil {
+				d.indices[parent] = make(map[int]bool)
 			}
+			d.indices[parent][index] = true
 		}
 	}
-	return size
 }
 
-var fhType = reflect.TypeOf((*multipart.FileHeader)(nil))
+// Determines whether any key was posted below path
+func (d *formData) hasPrefix(path string) bool {
+	return d.prefixes[path]
+}
 
-// Takes values from the form data and puts them into a struct
-func mapForm(path string, formStruct reflect.Value, form map[string][]string, formfile map[string][]*multipart.FileHeader) error {
-	formStruct = reflect.Indirect(formStruct)
-	typ := formStruct.Type()
+// Returns the sorted indices posted for the slice at path, and the size of
+// the slice needed to hold them. Indices above MaxSliceIndex are reported
+// and skipped, and so are all of them when the slice would hold more than
+// MaxSliceLength elements. With CompactSliceIndices the size is the number
+// of indices, as they are laid out consecutively.
+func (d *formData) sliceIndices(path string, errs *Errors) ([]int, int) {
+	indices := make([]int, 0, len(d.indices[path]))
+	for index := range d.indices[path] {
+		if index > MaxSliceIndex {
+			errs.Add(path+"."+strconv.Itoa(index), IndexError, fmt.Sprintf("index exceeds the maximum of %d", MaxSliceIndex))
+			continue
+		}
+		indices = append(indices, index)
+	}
+	if len(indices) == 0 {
+		return nil, 0
+	}
+	sort.Ints(indices)
+
+	size := indices[len(indices)-1] + 1
+	if CompactSliceIndices {
+		size = len(indices)
+	}
+	if size > MaxSliceLength {
+		errs.Add(path, IndexError, fmt.Sprintf("number of elements exceeds the maximum of %d", MaxSliceLength))
+		return nil, 0
+	}
+	return indices, size
+}
+
+// Returns the sorted segments posted directly below path, like the keys
+// color and size for attrs.color and attrs.size
+func (d *formData) childKeys(path string) []string {
+	keys := make([]string, 0, len(d.children[path]))
+	for key := range d.children[path] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type fieldKind int
+
+const (
+	kindValue fieldKind = iota
+	kindEmbedded
+	kindEmbeddedPtr
+	kindFile
+	kindFiles
+	kindStruct
+	kindStructPtr
+	kindStructSlice
+	kindMap
+)
+
+// The compiled mapping of a single struct field onto the form
+type fieldPlan struct {
+	index int
+	name  string
+	kind  fieldKind
+
+	// Layout and location of time fields, from the time_format and
+	// time_location tags
+	timeFormat   string
+	timeLocation *time.Location
+	locationErr  error
+
+	// Whether the field type decodes itself from all of its values or from
+	// a single text value
+	formUnmarshaler bool
+	textUnmarshaler bool
+
+	// The plan of the elements of a map field
+	elem *fieldPlan
+}
+
+// Compiled field plans, keyed by struct type and tag
+var planCache sync.Map
+
+type planKey struct {
+	typ reflect.Type
+	tag string
+}
+
+var (
+	fhType              = reflect.TypeOf((*multipart.FileHeader)(nil))
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	formUnmarshalerType = reflect.TypeOf((*FormUnmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Returns the plan of the fields of typ that can be mapped from a form,
+// named by the given struct tag (see lookupTag)
+func structPlan(typ reflect.Type, tag string) []fieldPlan {
+	key := planKey{typ: typ, tag: tag}
+	if plan, ok := planCache.Load(key); ok {
+		return plan.([]fieldPlan)
+	}
 
+	plan := make([]fieldPlan, 0, typ.NumField())
 	for i := 0; i < typ.NumField(); i++ {
 		typeField := typ.Field(i)
-		structField := formStruct.Field(i)
+		if typeField.PkgPath != "" && !typeField.Anonymous {
+			continue
+		}
 
-		inputFieldName := typeField.Tag.Get("form")
+		inputFieldName := lookupTag(typeField, tag)
 		if inputFieldName == "" {
 			inputFieldName = strings.ToLower(typeField.Name)
 		}
 
-		if typeField.Anonymous {
-			if typeField.Type.Kind() == reflect.Ptr {
-				structField.Set(reflect.New(typeField.Type.Elem()))
-				if err := mapForm(path, structField.Elem(), form, formfile); err != nil {
-					return err
-				}
-				if reflect.DeepEqual(structField.Elem().Interface(), reflect.Zero(structField.Elem().Type()).Interface()) {
-					structField.Set(reflect.Zero(structField.Type()))
-				}
-			} else {
-				if err := mapForm(path, structField, form, formfile); err != nil {
-					return err
-				}
+		field := fieldPlan{index: i, name: inputFieldName}
+		fieldType := typeField.Type
+		switch {
+		case isTimeType(fieldType) || isUnmarshalerType(fieldType):
+			if lookupTag(typeField, tag) == "" {
+				continue
+			}
+			field.kind = kindValue
+		case typeField.Anonymous && fieldType.Kind() == reflect.Ptr:
+			field.kind = kindEmbeddedPtr
+		case typeField.Anonymous:
+			field.kind = kindEmbedded
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem() == fhType:
+			field.kind = kindFiles
+		case fieldType == fhType:
+			field.kind = kindFile
+		case fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct:
+			field.kind = kindStructPtr
+		case fieldType.Kind() == reflect.Struct:
+			field.kind = kindStruct
+		case fieldType.Kind() == reflect.Slice &&
+			(fieldType.Elem().Kind() == reflect.Struct ||
+				(fieldType.Elem().Kind() == reflect.Ptr && fieldType.Elem().Elem().Kind() == reflect.Struct)):
+			field.kind = kindStructSlice
+		case fieldType.Kind() == reflect.Map:
+			field.kind = kindMap
+			field.elem = &fieldPlan{kind: kindValue}
+			if elemType := fieldType.Elem(); !isTimeType(elemType) && !isUnmarshalerType(elemType) &&
+				(elemType.Kind() == reflect.Struct || (elemType.Kind() == reflect.Ptr && elemType.Elem().Kind() == reflect.Struct)) {
+				field.elem.kind = kindStruct
+			}
+			field.elem.compileValue(typeField, fieldType.Elem())
+		case lookupTag(typeField, tag) != "":
+			field.kind = kindValue
+		default:
+			continue
+		}
+		// Sources other than forms only fill the maps, slices of structs
+		// and struct pointers tagged for them, so a value always comes from
+		// the source its field names
+		if tag != "form" && lookupTag(typeField, tag) == "" &&
+			(field.kind == kindMap || field.kind == kindStructSlice || field.kind == kindStructPtr) {
+			continue
+		}
+		if field.kind == kindValue {
+			field.compileValue(typeField, fieldType)
+		}
+		plan = append(plan, field)
+	}
+
+	actual, _ := planCache.LoadOrStore(key, plan)
+	return actual.([]fieldPlan)
+}
+
+// Returns the value of the struct tag of field. The tag can list fallbacks
+// separated by commas, like "csv,form", of which the first present is used.
+func lookupTag(field reflect.StructField, tag string) string {
+	for _, name := range strings.Split(tag, ",") {
+		if value := field.Tag.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Returns the names of the value fields of typ, including those of embedded
+// structs, for sources that can only be looked up by name.
+func tagNames(typ reflect.Type, tag string) []string {
+	names := []string{}
+	for _, field := range structPlan(typ, tag) {
+		switch field.kind {
+		case kindValue:
+			names = append(names, field.name)
+		case kindEmbedded, kindEmbeddedPtr:
+			fieldType := typ.Field(field.index).Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
 			}
-		} else if structField.Kind() == reflect.Slice && structField.Type().Elem() == fhType {
+			if fieldType.Kind() == reflect.Struct {
+				names = append(names, tagNames(fieldType, tag)...)
+			}
+		}
+	}
+	return names
+}
+
+// Compiles the conversion of values of typ, using the tags of the field
+func (f *fieldPlan) compileValue(typeField reflect.StructField, typ reflect.Type) {
+	if isTimeType(typ) {
+		f.timeFormat = typeField.Tag.Get("time_format")
+		if f.timeFormat == "" {
+			f.timeFormat = time.RFC3339
+		}
+		f.timeLocation = time.UTC
+		if location := typeField.Tag.Get("time_location"); location != "" {
+			f.timeLocation, f.locationErr = time.LoadLocation(location)
+		}
+	}
+	f.formUnmarshaler = implements(typ, formUnmarshalerType)
+	f.textUnmarshaler = implements(typ, textUnmarshalerType)
+}
+
+// Takes values from the form data and puts them into a struct
+func mapForm(path string, formStruct reflect.Value, form *formData, errs *Errors) {
+	formStruct = reflect.Indirect(formStruct)
+
+	for _, field := range structPlan(formStruct.Type(), form.tag) {
+		structField := formStruct.Field(field.index)
+		inputFieldName := field.name
+
+		switch field.kind {
+		case kindEmbeddedPtr:
+			// Keeps the struct bound before from another source, and only
+			// resets the one allocated here when nothing was filled in
+			if !structField.IsNil() {
+				mapForm(path, structField.Elem(), form, errs)
+				break
+			}
+			structField.Set(reflect.New(structField.Type().Elem()))
+			mapForm(path, structField.Elem(), form, errs)
+			if reflect.DeepEqual(structField.Elem().Interface(), reflect.Zero(structField.Elem().Type()).Interface()) {
+				structField.Set(reflect.Zero(structField.Type()))
+			}
+		case kindEmbedded:
+			mapForm(path, structField, form, errs)
+		case kindFiles:
 			//slice of file uploads
-			inputFile, exists := formfile[path+inputFieldName]
+			inputFile, exists := form.files[path+inputFieldName]
 			if exists {
 				numFiles := len(inputFile)
 				if numFiles > 0 {
@@ -346,35 +636,25 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 					structField.Set(slice)
 				}
 			}
-		} else if structField.Type() == fhType {
+		case kindFile:
 			//single file
-			inputFile, exists := formfile[path+inputFieldName]
+			inputFile, exists := form.files[path+inputFieldName]
 			if exists && len(inputFile) >= 1 {
 				structField.Set(reflect.ValueOf(inputFile[0]))
 			}
-		} else if typeField.Type.Kind() == reflect.Ptr && typeField.Type.Elem().Kind() == reflect.Struct {
+		case kindStructPtr:
 			//find if we have posted this field and or need to init the pointer
-			for key, _ := range form {
-				if strings.HasPrefix(key, path+inputFieldName+".") {
-					if structField.IsNil() {
-						structField.Set(reflect.New(typeField.Type.Elem()))
-					}
-					if err := mapForm(path+inputFieldName+".", structField.Elem(), form, formfile); err != nil {
-						return err
-					}
-					break
+			if form.hasPrefix(path + inputFieldName) {
+				if structField.IsNil() {
+					structField.Set(reflect.New(structField.Type().Elem()))
 				}
+				mapForm(path+inputFieldName+".", structField.Elem(), form, errs)
 			}
-		} else if typeField.Type.Kind() == reflect.Struct {
-			if err := mapForm(path+inputFieldName+".", structField, form, formfile); err != nil {
-				return err
-			}
-		} else if typeField.Type.Kind() == reflect.Slice &&
-			(typeField.Type.Elem().Kind() == reflect.Struct ||
-				(typeField.Type.Elem().Kind() == reflect.Ptr && typeField.Type.Elem().Elem().Kind() == reflect.Struct)) {
-
+		case kindStruct:
+			mapForm(path+inputFieldName+".", structField, form, errs)
+		case kindStructSlice:
 			//size slice (if necessary)
-			size := pathSliceSize(path+inputFieldName, form)
+			indices, size := form.sliceIndices(path+inputFieldName, errs)
 			if structField.Len() < size {
 				value := reflect.MakeSlice(structField.Type(), size, size)
 				if structField.Len() > 0 {
@@ -389,87 +669,319 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 				if sliceValue.Kind() == reflect.Ptr && sliceValue.IsNil() {
 					sliceValue.Set(reflect.New(sliceValue.Type().Elem()))
 				}
-				if err := mapForm(path+inputFieldName+"."+strconv.Itoa(i)+".", sliceValue, form, formfile); err != nil {
-					return err
+			}
+			for i, index := range indices {
+				position := index
+				if CompactSliceIndices {
+					position = i
 				}
+				mapForm(path+inputFieldName+"."+strconv.Itoa(index)+".", structField.Index(position), form, errs)
 			}
-
-		} else if inputFieldName := typeField.Tag.Get("form"); inputFieldName != "" {
+		case kindMap:
+			mapFormMap(path+inputFieldName, field.elem, structField, form, errs)
+		case kindValue:
 			if !structField.CanSet() {
 				continue
 			}
 
-			inputValue, exists := form[path+inputFieldName]
-			if exists {
-				numElems := len(inputValue)
-				if structField.Kind() == reflect.Slice && numElems > 0 {
-					sliceOf := structField.Type().Elem().Kind()
-					slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
-					for i := 0; i < numElems; i++ {
-						setWithProperType(sliceOf, inputValue[i], slice.Index(i), inputFieldName)
-					}
-					formStruct.Field(i).Set(slice)
-				} else {
-					setWithProperType(typeField.Type.Kind(), inputValue[0], structField, inputFieldName)
-				}
+			field.mapValues(path+inputFieldName, structField, form, errs)
+		}
+	}
+}
+
+// Takes the values posted below path, like attrs.color, and puts them into
+// the map by their key.
+func mapFormMap(path string, elem *fieldPlan, mapField reflect.Value, form *formData, errs *Errors) {
+	keys := form.childKeys(path)
+	if len(keys) == 0 {
+		return
+	}
+
+	mapType := mapField.Type()
+	if mapField.IsNil() {
+		mapField.Set(reflect.MakeMap(mapType))
+	}
+
+	for _, key := range keys {
+		keyValue := reflect.New(mapType.Key()).Elem()
+		if err := setWithProperType(keyValue.Kind(), key, keyValue); err != nil {
+			errs.addConversion(path+"."+key, key, err)
+			continue
+		}
+
+		elemValue := reflect.New(mapType.Elem()).Elem()
+		if existing := mapField.MapIndex(keyValue); existing.IsValid() {
+			elemValue.Set(existing)
+		}
+
+		if elem.kind == kindStruct {
+			if !form.hasPrefix(path + "." + key) {
+				continue
 			}
+			if elemValue.Kind() == reflect.Ptr && elemValue.IsNil() {
+				elemValue.Set(reflect.New(mapType.Elem().Elem()))
+			}
+			mapForm(path+"."+key+".", elemValue, form, errs)
+		} else if !elem.mapValues(path+"."+key, elemValue, form, errs) {
+			continue
 		}
+		mapField.SetMapIndex(keyValue, elemValue)
+	}
+}
+
+// Converts the values posted for path into the field, either from repeated
+// keys (tags=a&tags=b) or, for slices and arrays, from indexed keys
+// (tags.0=a&tags.1=b). Returns whether any value was posted.
+func (f *fieldPlan) mapValues(path string, structField reflect.Value, form *formData, errs *Errors) bool {
+	if inputValue, exists := form.values[path]; exists {
+		f.setValues(path, inputValue, structField, errs)
+		return true
+	}
+	if f.isList(structField) && form.hasPrefix(path) {
+		f.setIndexedValues(path, structField, form, errs)
+		return true
 	}
+	return false
+}
+
+// Converts the values posted for a field into it. Slices and arrays receive
+// all the values, other fields only the first.
+func (f *fieldPlan) setValues(path string, inputValue []string, structField reflect.Value, errs *Errors) {
+	numElems := len(inputValue)
+	if f.formUnmarshaler {
+		if err := unmarshalForm(inputValue, structField); err != nil {
+			errs.addConversion(path, strings.Join(inputValue, ","), err)
+		}
+	} else if structField.Kind() == reflect.Slice && !f.textUnmarshaler && numElems > 0 {
+		slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
+		for i := 0; i < numElems; i++ {
+			if err := f.setValue(inputValue[i], slice.Index(i)); err != nil {
+				errs.addConversion(path, inputValue[i], err)
+			}
+		}
+		structField.Set(slice)
+	} else if structField.Kind() == reflect.Array && !f.textUnmarshaler {
+		if numElems > structField.Len() {
+			errs.Add(path, IndexError, fmt.Sprintf("number of elements exceeds the array length of %d", structField.Len()))
+			numElems = structField.Len()
+		}
+		for i := 0; i < numElems; i++ {
+			if err := f.setValue(inputValue[i], structField.Index(i)); err != nil {
+				errs.addConversion(path, inputValue[i], err)
+			}
+		}
+	} else if numElems > 0 {
+		if err := f.setValue(inputValue[0], structField); err != nil {
+			errs.addConversion(path, inputValue[0], err)
+		}
+	}
+}
+
+// Converts the values posted with indexed keys, like tags.0 and tags.1, into
+// the slice or array in the order of their index.
+func (f *fieldPlan) setIndexedValues(path string, structField reflect.Value, form *formData, errs *Errors) {
+	indices, size := form.sliceIndices(path, errs)
+	if size == 0 {
+		return
+	}
+
+	if structField.Kind() == reflect.Slice {
+		structField.Set(reflect.MakeSlice(structField.Type(), size, size))
+	}
+	for i, index := range indices {
+		position := index
+		if CompactSliceIndices {
+			position = i
+		}
+		if position >= structField.Len() {
+			errs.Add(path+"."+strconv.Itoa(index), IndexError, fmt.Sprintf("index exceeds the array length of %d", structField.Len()))
+			continue
+		}
+
+		elemPath := path + "." + strconv.Itoa(index)
+		inputValue, exists := form.values[elemPath]
+		if !exists || len(inputValue) == 0 {
+			continue
+		}
+		if err := f.setValue(inputValue[0], structField.Index(position)); err != nil {
+			errs.addConversion(elemPath, inputValue[0], err)
+		}
+	}
+}
+
+// Determines whether the field is a slice or array of separate values
+func (f *fieldPlan) isList(structField reflect.Value) bool {
+	kind := structField.Kind()
+	return (kind == reflect.Slice || kind == reflect.Array) && !f.formUnmarshaler && !f.textUnmarshaler
+}
+
+// Converts the input value into the field, handling the time types before
+// falling back to the primitive kinds of setWithProperType.
+func (f *fieldPlan) setValue(val string, structField reflect.Value) error {
+	switch structField.Type() {
+	case timeType:
+		return f.setTime(val, structField)
+	case reflect.PtrTo(timeType):
+		if val == "" {
+			structField.Set(reflect.Zero(structField.Type()))
+			return nil
+		}
+		t := reflect.New(timeType)
+		if err := f.setTime(val, t.Elem()); err != nil {
+			return err
+		}
+		structField.Set(t)
+		return nil
+	case durationType:
+		if val == "" {
+			structField.SetInt(0)
+			return nil
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		structField.SetInt(int64(d))
+		return nil
+	}
+
+	if implements(structField.Type(), formUnmarshalerType) {
+		return unmarshalForm([]string{val}, structField)
+	}
+	if implements(structField.Type(), textUnmarshalerType) {
+		if val == "" && structField.Kind() == reflect.Ptr {
+			structField.Set(reflect.Zero(structField.Type()))
+			return nil
+		}
+		return unmarshalerOf(structField).(encoding.TextUnmarshaler).UnmarshalText([]byte(val))
+	}
+	return setWithProperType(structField.Kind(), val, structField)
+}
+
+// Lets a FormUnmarshaler field decode the posted values
+func unmarshalForm(values []string, structField reflect.Value) error {
+	return unmarshalerOf(structField).(FormUnmarshaler).UnmarshalForm(values)
+}
+
+// Returns the field as the receiver of its unmarshal method, allocating
+// nil pointers and taking the address of values.
+func unmarshalerOf(structField reflect.Value) interface{} {
+	if structField.Kind() == reflect.Ptr {
+		if structField.IsNil() {
+			structField.Set(reflect.New(structField.Type().Elem()))
+		}
+		return structField.Interface()
+	}
+	return structField.Addr().Interface()
+}
+
+// Determines whether typ, or a pointer to it, implements iface
+func implements(typ reflect.Type, iface reflect.Type) bool {
+	return typ.Implements(iface) || (typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).Implements(iface))
+}
+
+// Determines whether typ, or the element of a slice typ, decodes itself
+func isUnmarshalerType(typ reflect.Type) bool {
+	if implements(typ, formUnmarshalerType) || implements(typ, textUnmarshalerType) {
+		return true
+	}
+	if typ.Kind() == reflect.Slice {
+		return implements(typ.Elem(), formUnmarshalerType) || implements(typ.Elem(), textUnmarshalerType)
+	}
+	return false
+}
+
+// Parses a time in the layout and location of the field. An empty value
+// results in the zero time.
+func (f *fieldPlan) setTime(val string, structField reflect.Value) error {
+	if val == "" {
+		structField.Set(reflect.Zero(timeType))
+		return nil
+	}
+	if f.locationErr != nil {
+		return f.locationErr
+	}
+	t, err := time.ParseInLocation(f.timeFormat, val, f.timeLocation)
+	if err != nil {
+		return err
+	}
+	structField.Set(reflect.ValueOf(t))
 	return nil
 }
 
+// Determines whether typ is time.Time or a pointer or slice of it
+func isTimeType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ == timeType || typ.Kind() == reflect.Ptr && typ.Elem() == timeType
+}
+
 // This sets the value in a struct of an indeterminate type to the
 // matching value from the request (via Form middleware) in the
 // same type, so that not all deserialize values have to be strings.
 // Supported types are string, int, float, and bool.
-func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value, nameInTag string) {
+// An error is returned when the value cannot be converted or does not fit
+// the size of the field, in which case the field is left untouched.
+func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value) error {
 	switch valueKind {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if val == "" {
 			val = "0"
 		}
 
-		if intVal, err := strconv.ParseInt(val, 10, 64); err == nil {
-			structField.SetInt(intVal)
+		intVal, err := strconv.ParseInt(val, 10, structField.Type().Bits())
+		if err != nil {
+			return err
 		}
+		structField.SetInt(intVal)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if val == "" {
 			val = "0"
 		}
 
-		if uintVal, err := strconv.ParseUint(val, 10, 64); err == nil {
-			structField.SetUint(uintVal)
+		uintVal, err := strconv.ParseUint(val, 10, structField.Type().Bits())
+		if err != nil {
+			return err
 		}
+		structField.SetUint(uintVal)
 	case reflect.Bool:
 		if val == "on" {
 			structField.SetBool(true)
-			return
+			return nil
 		}
 
 		if val == "" {
 			val = "false"
 		}
 
-		if boolVal, err := strconv.ParseBool(val); err == nil {
-			structField.SetBool(boolVal)
+		boolVal, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
 		}
+		structField.SetBool(boolVal)
 	case reflect.Float32:
 		if val == "" {
 			val = "0.0"
 		}
 
-		if floatVal, err := strconv.ParseFloat(val, 32); err == nil {
-			structField.SetFloat(floatVal)
+		floatVal, err := strconv.ParseFloat(val, 32)
+		if err != nil {
+			return err
 		}
+		structField.SetFloat(floatVal)
 	case reflect.Float64:
 		if val == "" {
 			val = "0.0"
 		}
 
-		if floatVal, err := strconv.ParseFloat(val, 64); err == nil {
-			structField.SetFloat(floatVal)
+		floatVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return err
 		}
+		structField.SetFloat(floatVal)
 	case reflect.String:
 		structField.SetString(val)
 	}
+	return nil
 }
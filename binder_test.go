@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"reflect"
+	"sync"
+
+	. "gopkg.in/check.v1"
+)
+
+type binderSuite struct{}
+
+var _ = Suite(&binderSuite{})
+
+func (s *binderSuite) Test_FormDataIndex(c *C) {
+	data := newFormData("form", map[string][]string{
+		"title":                   {"Glorious Post Title"},
+		"author.name":             {"Matt Holt"},
+		"reviewers.1.name":        {"A. Jolie"},
+		"reviewers.4.name":        {"M. Boke"},
+		"book.reviewers.2.name":   {"A. Jolie"},
+		"book.reviewers.x.name":   {"invalid"},
+		"book.reviewers.-1.name":  {"invalid"},
+		"book.contributors.0":     {"not a struct"},
+		"chapters.1.pages.3.text": {"text"},
+	}, nil)
+
+	c.Assert(data.hasPrefix("author"), Equals, true)
+	c.Assert(data.hasPrefix("title"), Equals, false)
+	c.Assert(data.hasPrefix("reviewers.1"), Equals, true)
+	c.Assert(data.hasPrefix("auth"), Equals, false)
+
+	errs := Errors{}
+	indices, size := data.sliceIndices("reviewers", &errs)
+	c.Assert(indices, DeepEquals, []int{1, 4})
+	c.Assert(size, Equals, 5)
+	indices, size = data.sliceIndices("book.reviewers", &errs)
+	c.Assert(indices, DeepEquals, []int{2})
+	c.Assert(size, Equals, 3)
+	indices, size = data.sliceIndices("book.contributors", &errs)
+	c.Assert(indices, DeepEquals, []int{0})
+	c.Assert(size, Equals, 1)
+	_, size = data.sliceIndices("chapters", &errs)
+	c.Assert(size, Equals, 2)
+	_, size = data.sliceIndices("chapters.1.pages", &errs)
+	c.Assert(size, Equals, 4)
+	_, size = data.sliceIndices("author", &errs)
+	c.Assert(size, Equals, 0)
+	c.Assert(errs, HasLen, 0)
+}
+
+func (s *binderSuite) Test_StructPlanCached(c *C) {
+	typ := reflect.TypeOf(BlogPost{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			structPlan(typ, "form")
+		}()
+	}
+	wg.Wait()
+
+	plan := structPlan(typ, "form")
+	c.Assert(reflect.ValueOf(structPlan(typ, "form")).Pointer(), Equals, reflect.ValueOf(plan).Pointer())
+
+	names := []string{}
+	for _, field := range plan {
+		names = append(names, field.name)
+	}
+	c.Assert(names, DeepEquals, []string{"post", "id", "-", "rating", "author", "coauthor", "readers", "contributors", "headerImage", "picture"})
+}
+
+func (s *binderSuite) Test_DottedKey(c *C) {
+	for key, expected := range map[string]string{
+		"title":                "title",
+		"reviewers.1.name":     "reviewers.1.name",
+		"reviewers[1][name]":   "reviewers.1.name",
+		"reviewers[1].name":    "reviewers.1.name",
+		"book[reviewers][2][]": "book.reviewers.2",
+		"tags[]":               "tags",
+		"tags[][name]":         "tags[][name]",
+		"tags[1":               "tags[1",
+		"[1]":                  "[1]",
+	} {
+		c.Assert(dottedKey(key), Equals, expected, Commentf("key %s", key))
+	}
+}
+
+func (s *binderSuite) Test_NormalizeKeys(c *C) {
+	values := map[string][]string{
+		"tags":       {"a"},
+		"tags[]":     {"b", "c"},
+		"author[id]": {"1"},
+	}
+	normalized := normalizeKeys(values)
+
+	c.Assert(normalized, DeepEquals, map[string][]string{
+		"tags":      {"a", "b", "c"},
+		"author.id": {"1"},
+	})
+	c.Assert(values["tags"], DeepEquals, []string{"a"})
+}
@@ -0,0 +1,104 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// The maximum nesting of arrays and maps in a CBOR payload, which is the
+// limit encoding/json enforces for JSON.
+const maxNestingDepth = 10000
+
+// Decodes with the nesting limit of JSON. Arrays and maps keep the limit of
+// the decoder of 131072 elements and pairs.
+var cborDecMode = mustDecMode(cbor.DecOptions{
+	MaxNestedLevels: maxNestingDepth,
+})
+
+var cborFieldPath = fieldPathFinder{
+	tags:      "cbor,json",
+	unmarshal: cborDecMode.Unmarshal,
+	raw:       reflect.TypeOf(cbor.RawMessage{}),
+	unmarshalers: []reflect.Type{
+		reflect.TypeOf((*cbor.Unmarshaler)(nil)).Elem(),
+	},
+}
+
+type cborBinding struct{}
+
+func (_ cborBinding) Name() string {
+	return "cbor"
+}
+
+func (_ cborBinding) tags() []string {
+	return []string{"cbor", "json"}
+}
+
+// CBOR deserializes a CBOR payload from the request into the struct that
+// is passed in and validates the result. Fields are matched by their cbor
+// tag, or their json tag when they have none. Payloads are subject to the
+// same MaxBodySize and nesting limits as JSON.
+func (b cborBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ cborBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	if req.Body != nil {
+		defer req.Body.Close()
+		data, err := io.ReadAll(limitedBody(req))
+		if err != nil {
+			return deserializationError(err.Error(), err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		if err := cborDecMode.NewDecoder(bytes.NewReader(data)).Decode(dst); err != nil {
+			return cborErrors(data, v.Type(), err)
+		}
+	}
+	return nil
+}
+
+// Translates a decoder error into field errors. The decoder only names the
+// field within its own struct, so the payload is decoded again value by
+// value to find the path of the field, like jsonErrors reports it.
+func cborErrors(data []byte, typ reflect.Type, err error) Errors {
+	var typeErr *cbor.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return deserializationError(err.Error(), err)
+	}
+
+	field := cborFieldPath.find(data, typ)
+	if field == "" {
+		field = typeErr.StructFieldName
+		if i := strings.LastIndex(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+	}
+	return Errors{&FieldError{
+		Field:          field,
+		Classification: TypeError,
+		Message:        fmt.Sprintf("cannot use %s value as %s", typeErr.CBORType, typeErr.GoType),
+		Err:            err,
+	}}
+}
+
+func mustDecMode(opts cbor.DecOptions) cbor.DecMode {
+	mode, err := opts.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}
@@ -0,0 +1,164 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	. "gopkg.in/check.v1"
+)
+
+type (
+	Reading struct {
+		Sensor  string      `cbor:"s" json:"sensor" validate:"Required"`
+		Celsius float64     `json:"celsius"`
+		Tags    []string    `json:"tags"`
+		Extra   interface{} `json:"extra"`
+	}
+
+	Station struct {
+		Name     string    `json:"name"`
+		Location Location  `json:"location"`
+		Readings []Reading `json:"readings"`
+	}
+
+	Location struct {
+		Latitude float64 `json:"lat"`
+	}
+
+	Region struct {
+		Subregions []Region `json:"subregions"`
+	}
+)
+
+type cborSuite struct{}
+
+var _ = Suite(&cborSuite{})
+
+func (s *cborSuite) Test_NotByReference(c *C) {
+	reading := Reading{}
+	err := CBOR.Bind(reading, newCBORRequest(map[string]interface{}{"s": "t1"}))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *cborSuite) Test_HappyPath(c *C) {
+	reading := Reading{}
+	req := newCBORRequest(map[string]interface{}{"s": "t1", "celsius": 21.5, "tags": []string{"roof"}})
+	err := CBOR.Bind(&reading, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(reading, DeepEquals, Reading{Sensor: "t1", Celsius: 21.5, Tags: []string{"roof"}})
+}
+
+func (s *cborSuite) Test_EmptyPayload(c *C) {
+	reading := Reading{Sensor: "t1"}
+	err := CBOR.Bind(&reading, newRequest(`POST`, ``, ``, MIMECBOR))
+
+	c.Assert(err, IsNil)
+	c.Assert(reading, DeepEquals, Reading{Sensor: "t1"})
+}
+
+func (s *cborSuite) Test_Validation(c *C) {
+	reading := Reading{}
+	err := CBOR.Bind(&reading, newCBORRequest(map[string]interface{}{"celsius": 3}))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "s")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+}
+
+func (s *cborSuite) Test_TypeMismatchReportsField(c *C) {
+	reading := Reading{}
+	err := CBOR.Bind(&reading, newCBORRequest(map[string]interface{}{"s": "t1", "celsius": "warm"}))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "celsius")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+}
+
+func (s *cborSuite) Test_NestedTypeMismatchReportsPath(c *C) {
+	station := Station{}
+	err := CBOR.Bind(&station, newCBORRequest(map[string]interface{}{"name": "roof", "location": map[string]interface{}{"lat": "north"}}))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "location.lat")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+
+	station = Station{}
+	err = CBOR.Bind(&station, newCBORRequest(map[string]interface{}{"readings": []interface{}{
+		map[string]interface{}{"s": "t1"},
+		map[string]interface{}{"s": "t2", "celsius": "warm"},
+	}}))
+
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "readings.1.celsius")
+}
+
+func (s *cborSuite) Test_DeeplyNestedTypeMismatch(c *C) {
+	payload := strings.Repeat("\xa1\x6asubregions\x81", 2000) + "\x64leaf"
+
+	start := time.Now()
+	region := Region{}
+	err := CBOR.Bind(&region, newRequest(`POST`, ``, payload, MIMECBOR))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "subregions")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(time.Since(start) < time.Second, Equals, true)
+}
+
+func (s *cborSuite) Test_ArrayLimit(c *C) {
+	reading := Reading{}
+	err := CBOR.Bind(&reading, newCBORRequest(map[string]interface{}{"s": "t1", "tags": make([]string, 131073)}))
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *cborSuite) Test_NestingLimit(c *C) {
+	payload := "\xa2\x61s\x62t1\x65extra" + strings.Repeat("\x81", maxNestingDepth) + "\x00"
+
+	reading := Reading{}
+	err := CBOR.Bind(&reading, newRequest(`POST`, ``, payload, MIMECBOR))
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *cborSuite) Test_MaxBodySize(c *C) {
+	MaxBodySize = 16
+	defer func() { MaxBodySize = int64(1024 * 1024 * 16) }()
+
+	reading := Reading{}
+	err := CBOR.Bind(&reading, newCBORRequest(map[string]interface{}{"s": strings.Repeat("t", 32)}))
+
+	var maxErr *http.MaxBytesError
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.As(err, &maxErr), Equals, true)
+}
+
+func (s *cborSuite) Test_Bind(c *C) {
+	reading := Reading{}
+	err := Bind(&reading, newCBORRequest(map[string]interface{}{"s": "t1"}))
+
+	c.Assert(err, IsNil)
+	c.Assert(reading.Sensor, Equals, "t1")
+}
+
+func newCBORRequest(payload interface{}) *http.Request {
+	data, err := cbor.Marshal(payload)
+	if err != nil {
+		panic(err)
+	}
+	return newRequest(`POST`, ``, string(data), MIMECBOR)
+}
@@ -0,0 +1,91 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+)
+
+var (
+	cookieType      = reflect.TypeOf((*http.Cookie)(nil))
+	cookieSliceType = reflect.TypeOf([]*http.Cookie(nil))
+)
+
+type cookieBinding struct{}
+
+func (_ cookieBinding) Name() string {
+	return "cookie"
+}
+
+func (_ cookieBinding) tags() []string {
+	return []string{"cookie"}
+}
+
+// Cookie maps the request cookies into the struct fields with a matching
+// cookie tag, like cookie:"session". The values are converted the same way
+// as with Form, and like with Path only the fields with a cookie tag are
+// validated. Fields of type *http.Cookie or []*http.Cookie receive the
+// cookies themselves.
+func (b cookieBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidatedSource(b, dst, req)
+}
+
+func (_ cookieBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
+	}
+
+	cookies := req.Cookies()
+	values := make(map[string][]string)
+	for _, name := range tagNames(v.Type(), "cookie") {
+		for _, cookie := range cookies {
+			if cookie.Name == name {
+				values[name] = append(values[name], cookie.Value)
+			}
+		}
+	}
+
+	mapForm("", v, newFormData("cookie", values, nil), errs)
+	mapCookies(v, cookies)
+	return nil
+}
+
+// Sets the *http.Cookie and []*http.Cookie fields, including those of
+// embedded structs, to the cookies with the name of their tag.
+func mapCookies(v reflect.Value, cookies []*http.Cookie) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := v.Field(i)
+
+		if typeField.Anonymous && typeField.Type.Kind() == reflect.Struct {
+			mapCookies(structField, cookies)
+			continue
+		}
+
+		name := typeField.Tag.Get("cookie")
+		if name == "" || name == "-" || !structField.CanSet() {
+			continue
+		}
+
+		switch typeField.Type {
+		case cookieType:
+			for _, cookie := range cookies {
+				if cookie.Name == name {
+					structField.Set(reflect.ValueOf(cookie))
+					break
+				}
+			}
+		case cookieSliceType:
+			matches := []*http.Cookie{}
+			for _, cookie := range cookies {
+				if cookie.Name == name {
+					matches = append(matches, cookie)
+				}
+			}
+			if len(matches) > 0 {
+				structField.Set(reflect.ValueOf(matches))
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type SessionCookies struct {
+	Session   string         `cookie:"session" validate:"Required"`
+	Visits    int            `cookie:"visits"`
+	Theme     []string       `cookie:"theme"`
+	Raw       *http.Cookie   `cookie:"session"`
+	Tracking  []*http.Cookie `cookie:"tracking"`
+	Missing   *http.Cookie   `cookie:"missing"`
+	Untouched string
+	Attrs     map[string]string
+}
+
+type cookieSuite struct{}
+
+var _ = Suite(&cookieSuite{})
+
+func (s *cookieSuite) Test_NotByReference(c *C) {
+	cookies := SessionCookies{}
+	req := newRequest(`GET`, ``, ``, ``)
+	err := Cookie.Bind(cookies, req)
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *cookieSuite) Test_HappyPath(c *C) {
+	cookies := SessionCookies{}
+	req := newRequest(`GET`, ``, ``, ``)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	req.AddCookie(&http.Cookie{Name: "visits", Value: "3"})
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "compact"})
+	req.AddCookie(&http.Cookie{Name: "tracking", Value: "a"})
+	req.AddCookie(&http.Cookie{Name: "tracking", Value: "b"})
+	err := Cookie.Bind(&cookies, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(cookies.Session, Equals, "s3cr3t")
+	c.Assert(cookies.Visits, Equals, 3)
+	c.Assert(cookies.Theme, DeepEquals, []string{"dark", "compact"})
+	c.Assert(cookies.Raw, NotNil)
+	c.Assert(cookies.Raw.Value, Equals, "s3cr3t")
+	c.Assert(cookies.Tracking, HasLen, 2)
+	c.Assert(cookies.Tracking[1].Value, Equals, "b")
+	c.Assert(cookies.Missing, IsNil)
+	c.Assert(cookies.Untouched, Equals, "")
+}
+
+func (s *cookieSuite) Test_Validation(c *C) {
+	cookies := SessionCookies{}
+	req := newRequest(`GET`, ``, ``, ``)
+	req.AddCookie(&http.Cookie{Name: "visits", Value: "3"})
+	err := Cookie.Bind(&cookies, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "session")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+}
+
+func (s *cookieSuite) Test_ValidatesCookieFieldsOnly(c *C) {
+	cart := struct {
+		Session string `cookie:"session" validate:"Required"`
+		Item    string `json:"item" validate:"Required"`
+	}{}
+	req := newRequest(`GET`, ``, ``, ``)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	err := Cookie.Bind(&cart, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(cart.Session, Equals, "s3cr3t")
+}
+
+func (s *cookieSuite) Test_IgnoresUntaggedFields(c *C) {
+	req := newRequest(`GET`, ``, ``, ``)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	req.AddCookie(&http.Cookie{Name: "untouched", Value: "x"})
+	req.AddCookie(&http.Cookie{Name: "attrs.role", Value: "admin"})
+
+	cookies := SessionCookies{}
+	err := Cookie.Bind(&cookies, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(cookies.Session, Equals, "abc")
+	c.Assert(cookies.Untouched, Equals, "")
+	c.Assert(cookies.Attrs, IsNil)
+}
@@ -0,0 +1,183 @@
+package binding
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// The tags naming the column of a field, the csv tag with the form tag as
+// fallback.
+const csvTags = "csv,form"
+
+type csvBinding struct{}
+
+func (_ csvBinding) Name() string {
+	return "csv"
+}
+
+func (_ csvBinding) tags() []string {
+	return []string{"csv", "form"}
+}
+
+// CSV deserializes a CSV body into the slice of structs dst points to, one
+// element per record, and validates the result. The first record is the
+// header, of which the columns are matched to the csv tags of the struct
+// fields, or their form tags when they have none. Cells are converted like
+// form values, but a cell that cannot be converted always fails the binding
+// with a TypeError for the index of the record and the field, like 1.price,
+// whose message reports the line and column of the cell.
+func (b csvBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+// A struct field a column is bound to
+type csvColumn struct {
+	index []int
+	field *fieldPlan
+}
+
+func (_ csvBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+	slice := v.Elem()
+	if slice.Kind() != reflect.Slice {
+		return ErrorInputIsNotSlice
+	}
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return ErrorInputIsNotSlice
+	}
+
+	if req.Body == nil {
+		return nil
+	}
+	defer req.Body.Close()
+
+	reader := csv.NewReader(limitedBody(req))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return csvErrors(err)
+	}
+
+	// Spreadsheet exports may start with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	fields := csvFields(structType, nil, map[string]csvColumn{})
+	columns := make([]*csvColumn, len(header))
+	for i, name := range header {
+		if column, ok := fields[strings.TrimSpace(name)]; ok {
+			columns[i] = &column
+		}
+	}
+
+	rows := reflect.MakeSlice(slice.Type(), 0, 0)
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return csvErrors(err)
+		}
+
+		elem := reflect.New(structType)
+		for i, cell := range record {
+			column := columns[i]
+			if column == nil {
+				continue
+			}
+			// Empty cells leave the embedded pointer structs nil
+			field, ok := csvField(elem.Elem(), column.index, cell != "")
+			if !ok {
+				continue
+			}
+			if err := column.field.setValue(cell, field); err != nil {
+				line, _ := reader.FieldPos(i)
+				*errs = append(*errs, &FieldError{
+					Field:          fmt.Sprintf("%d.%s", row, column.field.name),
+					Classification: TypeError,
+					Message:        fmt.Sprintf("line %d, column %d: %s", line, i+1, conversionMessage(cell, err)),
+					Value:          cell,
+					Err:            err,
+				})
+			}
+		}
+
+		if elemType.Kind() == reflect.Ptr {
+			rows = reflect.Append(rows, elem)
+		} else {
+			rows = reflect.Append(rows, elem.Elem())
+		}
+	}
+	slice.Set(rows)
+	return nil
+}
+
+// Returns the field of v at index. Nil embedded pointer structs on the way
+// are allocated when alloc is set, otherwise the field is not found.
+func csvField(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// Collects the fields of typ, including those of embedded structs and
+// embedded pointer structs, that a column can be bound to by name. Like
+// with Go field selectors, the fields of the outer struct take precedence
+// over the embedded ones.
+func csvFields(typ reflect.Type, index []int, fields map[string]csvColumn) map[string]csvColumn {
+	plan := structPlan(typ, csvTags)
+	for i := range plan {
+		field := &plan[i]
+		if field.kind != kindValue || field.name == "-" {
+			continue
+		}
+		if fieldType := typ.Field(field.index).Type; fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+			continue
+		}
+		if _, exists := fields[field.name]; !exists {
+			fields[field.name] = csvColumn{index: append(append([]int{}, index...), field.index), field: field}
+		}
+	}
+	for i := range plan {
+		field := &plan[i]
+		typeField := typ.Field(field.index)
+		switch {
+		case field.kind == kindEmbedded:
+			csvFields(typeField.Type, append(append([]int{}, index...), field.index), fields)
+		case field.kind == kindEmbeddedPtr && typeField.PkgPath == "" && typeField.Type.Elem().Kind() == reflect.Struct:
+			// Unexported embedded pointers cannot be allocated
+			csvFields(typeField.Type.Elem(), append(append([]int{}, index...), field.index), fields)
+		}
+	}
+	return fields
+}
+
+// Translates a reader error into field errors, keeping the line and column
+// reported by encoding/csv.
+func csvErrors(err error) Errors {
+	if e, ok := err.(*csv.ParseError); ok {
+		return deserializationError(fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Err), err)
+	}
+	return deserializationError(err.Error(), err)
+}
@@ -0,0 +1,141 @@
+package binding
+
+import (
+	"errors"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	Audit struct {
+		ImportedBy string `csv:"imported_by"`
+	}
+
+	StockItem struct {
+		Audit
+		Sku       string    `csv:"sku" validate:"Required"`
+		Name      string    `form:"name"`
+		Price     float64   `csv:"price" form:"cost"`
+		Stock     int       `csv:"stock"`
+		Available time.Time `csv:"available" time_format:"2006-01-02"`
+		Internal  string    `csv:"-"`
+	}
+
+	Review struct {
+		ReviewedBy string `csv:"reviewed_by"`
+	}
+
+	ReviewedItem struct {
+		*Review
+		Sku string `csv:"sku"`
+	}
+)
+
+type csvSuite struct{}
+
+var _ = Suite(&csvSuite{})
+
+func (s *csvSuite) Test_NotByReference(c *C) {
+	items := []StockItem{}
+	err := CSV.Bind(items, newRequest(`POST`, ``, "sku\nA1\n", MIMECSV))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *csvSuite) Test_NotASlice(c *C) {
+	item := StockItem{}
+	err := CSV.Bind(&item, newRequest(`POST`, ``, "sku\nA1\n", MIMECSV))
+
+	c.Assert(err, DeepEquals, ErrorInputIsNotSlice)
+}
+
+func (s *csvSuite) Test_HappyPath(c *C) {
+	payload := "\ufeffsku,name,price,stock,available,imported_by,cost,unknown\n" +
+		"A1,Chair,12.5,3,2024-05-01,ada,99,x\n" +
+		"B2,Table,,0,2024-06-01,grace,1,y\n"
+
+	items := []StockItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, payload, MIMECSV))
+
+	c.Assert(err, IsNil)
+	c.Assert(items, DeepEquals, []StockItem{
+		{Audit: Audit{ImportedBy: "ada"}, Sku: "A1", Name: "Chair", Price: 12.5, Stock: 3, Available: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{Audit: Audit{ImportedBy: "grace"}, Sku: "B2", Name: "Table", Available: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	})
+}
+
+func (s *csvSuite) Test_SliceOfPointers(c *C) {
+	items := []*StockItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, "sku,stock\nA1,3\n", MIMECSV))
+
+	c.Assert(err, IsNil)
+	c.Assert(items, DeepEquals, []*StockItem{{Sku: "A1", Stock: 3}})
+}
+
+func (s *csvSuite) Test_EmptyBody(c *C) {
+	items := []StockItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, ``, MIMECSV))
+
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 0)
+}
+
+func (s *csvSuite) Test_ConversionErrorReportsLineAndColumn(c *C) {
+	payload := "sku,price,stock\nA1,12.5,3\nB2,cheap,many\n"
+
+	items := []StockItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, payload, MIMECSV))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "1.price")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(errs[0].Message, Equals, `line 3, column 2: cannot convert "cheap": invalid syntax`)
+	c.Assert(errs[0].Value, Equals, "cheap")
+	c.Assert(errs[1].Field, Equals, "1.stock")
+	c.Assert(errs[1].Message, Equals, `line 3, column 3: cannot convert "many": invalid syntax`)
+	c.Assert(items, HasLen, 2)
+}
+
+func (s *csvSuite) Test_EmbeddedPointer(c *C) {
+	items := []ReviewedItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, "sku,reviewed_by\nA1,ada\nB2,\n", MIMECSV))
+
+	c.Assert(err, IsNil)
+	c.Assert(items, DeepEquals, []ReviewedItem{
+		{Review: &Review{ReviewedBy: "ada"}, Sku: "A1"},
+		{Sku: "B2"},
+	})
+}
+
+func (s *csvSuite) Test_Validation(c *C) {
+	items := []StockItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, "sku,name\nA1,Chair\n,Table\n", MIMECSV))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "1.sku")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+}
+
+func (s *csvSuite) Test_MalformedCsv(c *C) {
+	items := []StockItem{}
+	err := CSV.Bind(&items, newRequest(`POST`, ``, "sku,name\nA1,Chair\nB2\n", MIMECSV))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+	c.Assert(errs[0].Message, Equals, "line 3, column 1: wrong number of fields")
+}
+
+func (s *csvSuite) Test_Bind(c *C) {
+	items := []StockItem{}
+	err := Bind(&items, newRequest(`POST`, ``, "sku\nA1\n", `text/csv; charset=utf-8`))
+
+	c.Assert(err, IsNil)
+	c.Assert(items, DeepEquals, []StockItem{{Sku: "A1"}})
+}
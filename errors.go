@@ -0,0 +1,174 @@
+package binding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Classifications of a FieldError.
+const (
+	DeserializationError = "DeserializationError"
+	TypeError            = "TypeError"
+	IndexError           = "IndexError"
+	ConflictError        = "ConflictError"
+)
+
+// Maps a classification onto the sentinel error it still matches with
+// errors.Is, so callers checking for the old opaque errors keep working.
+var classificationErrors = map[string]error{
+	DeserializationError: ErrorDeserialization,
+	TypeError:            ErrorDeserialization,
+	IndexError:           ErrorDeserialization,
+	ConflictError:        ErrorValidation,
+	ValidationError:      ErrorValidation,
+}
+
+// FieldError describes a single failure while binding a request.
+// Field holds the path of the offending input (e.g. reviewers.1.name) and is
+// empty when the failure applies to the payload as a whole.
+type FieldError struct {
+	Field          string `json:"field,omitempty"`
+	Classification string `json:"classification,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Value          string `json:"value,omitempty"`
+	Err            error  `json:"-"`
+
+	// Set on the failures of validation rules and Validator implementations
+	validation bool
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap returns the underlying cause of the error, if any.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the classification of the error corresponds with target.
+// Every failure of a validation rule or Validator implementation matches
+// ErrorValidation, whatever its classification.
+func (e *FieldError) Is(target error) bool {
+	if e.validation {
+		return target == ErrorValidation
+	}
+	if sentinel, ok := classificationErrors[e.Classification]; ok {
+		return sentinel == target
+	}
+	return false
+}
+
+// Errors is the collection of field errors returned by the bindings.
+// It works with errors.Is and errors.As through each of its elements.
+type Errors []*FieldError
+
+// Add appends a new error for the given field path.
+func (e *Errors) Add(field, classification, message string) {
+	*e = append(*e, &FieldError{
+		Field:          field,
+		Classification: classification,
+		Message:        message,
+	})
+}
+
+// Len returns the number of errors.
+func (e Errors) Len() int {
+	return len(e)
+}
+
+// Has determines whether an error of the given classification exists.
+func (e Errors) Has(classification string) bool {
+	for _, err := range e {
+		if err.Classification == classification {
+			return true
+		}
+	}
+	return false
+}
+
+// Determines whether an error for the given field path exists.
+func (e Errors) hasField(field string) bool {
+	for _, err := range e {
+		if err.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the individual field errors to errors.Is and errors.As.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Returns the collection as an error, or nil when nothing was collected.
+func (e Errors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Records a value that could not be converted to the type of its field.
+// Conversion errors are only fatal in strict mode, otherwise they are
+// reported to OnConversionError.
+func (e *Errors) addConversion(field, value string, err error) {
+	fieldErr := &FieldError{
+		Field:          field,
+		Classification: TypeError,
+		Message:        conversionMessage(value, err),
+		Value:          value,
+		Err:            err,
+	}
+	if !StrictConversion {
+		if OnConversionError != nil {
+			OnConversionError(fieldErr)
+		}
+		return
+	}
+	*e = append(*e, fieldErr)
+}
+
+// Describes why value could not be converted, without repeating the value
+// and function that strconv includes in its errors.
+func conversionMessage(value string, err error) string {
+	reason := err
+	if numErr, ok := err.(*strconv.NumError); ok {
+		reason = numErr.Err
+	}
+	return fmt.Sprintf("cannot convert %q: %s", value, reason)
+}
+
+// Wraps a decoder failure that is not attributable to a single field.
+func deserializationError(message string, err error) Errors {
+	return Errors{&FieldError{
+		Classification: DeserializationError,
+		Message:        message,
+		Err:            err,
+	}}
+}
+
+// Appends name to the dotted field path, like author.name
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
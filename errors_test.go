@@ -0,0 +1,47 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type errorsSuite struct{}
+
+var _ = Suite(&errorsSuite{})
+
+func (s *errorsSuite) Test_Add(c *C) {
+	errs := Errors{}
+	errs.Add("reviewers.1.name", TypeError, "invalid value")
+
+	c.Assert(errs.Len(), Equals, 1)
+	c.Assert(errs.Has(TypeError), Equals, true)
+	c.Assert(errs.Has(DeserializationError), Equals, false)
+	c.Assert(errs.Error(), Equals, "reviewers.1.name: invalid value")
+}
+
+func (s *errorsSuite) Test_MatchesSentinel(c *C) {
+	cause := errors.New("cause")
+	var err error = Errors{
+		&FieldError{Field: "id", Classification: TypeError, Message: "invalid", Err: cause},
+	}
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.Is(err, cause), Equals, true)
+	c.Assert(errors.Is(err, ErrorEmptyContentType), Equals, false)
+
+	var fieldErr *FieldError
+	c.Assert(errors.As(err, &fieldErr), Equals, true)
+	c.Assert(fieldErr.Field, Equals, "id")
+}
+
+func (s *errorsSuite) Test_MarshalJSON(c *C) {
+	errs := Errors{
+		&FieldError{Field: "id", Classification: TypeError, Message: "invalid", Err: errors.New("cause")},
+	}
+	b, err := json.Marshal(errs)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `[{"field":"id","classification":"TypeError","message":"invalid"}]`)
+}
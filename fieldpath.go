@@ -0,0 +1,162 @@
+package binding
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The number of times the size of a payload that may be decoded again to
+// find the field it fails on, which bounds the work of deeply nested
+// payloads.
+const fieldPathBudget = 8
+
+// Finds the field a payload fails to decode into, for the decoders that do
+// not report it, by decoding the payload again value by value.
+type fieldPathFinder struct {
+	// The struct tags naming the fields, like "msgpack,json"
+	tags string
+
+	unmarshal func(data []byte, v interface{}) error
+
+	// The type holding an encoded value as is, like msgpack.RawMessage
+	raw reflect.Type
+
+	// The interfaces of types that decode themselves, of which the values
+	// are not looked into
+	unmarshalers []reflect.Type
+}
+
+// A value of a payload and the type it decodes into
+type encodedValue struct {
+	name string
+	data []byte
+	typ  reflect.Type
+}
+
+// Returns the path of the deepest value of data that cannot be decoded into
+// its field of typ, or "" when the value is not found within the budget.
+func (f fieldPathFinder) find(data []byte, typ reflect.Type) string {
+	budget := fieldPathBudget * len(data)
+	path, ok := f.walk(data, typ, "", &budget)
+	if !ok {
+		return ""
+	}
+	return path
+}
+
+func (f fieldPathFinder) walk(data []byte, typ reflect.Type, path string, budget *int) (string, bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if isTimeType(typ) {
+		return path, true
+	}
+	for _, iface := range f.unmarshalers {
+		if implements(typ, iface) {
+			return path, true
+		}
+	}
+
+	var values []encodedValue
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := f.object(data, budget)
+		if !ok {
+			return "", false
+		}
+		for _, field := range f.fields(typ, nil) {
+			if value, exists := object[field.name]; exists {
+				values = append(values, encodedValue{name: field.name, data: value, typ: field.typ})
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !spend(budget, data) {
+			return "", false
+		}
+		elems := reflect.New(reflect.SliceOf(f.raw))
+		if f.unmarshal(data, elems.Interface()) != nil {
+			return path, true
+		}
+		for i := 0; i < elems.Elem().Len(); i++ {
+			values = append(values, encodedValue{name: strconv.Itoa(i), data: elems.Elem().Index(i).Bytes(), typ: typ.Elem()})
+		}
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return path, true
+		}
+		object, ok := f.object(data, budget)
+		if !ok {
+			return "", false
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			values = append(values, encodedValue{name: key, data: object[key], typ: typ.Elem()})
+		}
+	}
+
+	for _, value := range values {
+		if !spend(budget, value.data) {
+			return "", false
+		}
+		if f.unmarshal(value.data, reflect.New(value.typ).Interface()) != nil {
+			return f.walk(value.data, value.typ, joinPath(path, value.name), budget)
+		}
+	}
+	return path, true
+}
+
+// Splits an encoded map into its encoded values by key. A payload that is
+// not a map has no values.
+func (f fieldPathFinder) object(data []byte, budget *int) (map[string][]byte, bool) {
+	if !spend(budget, data) {
+		return nil, false
+	}
+	values := reflect.New(reflect.MapOf(reflect.TypeOf(""), f.raw))
+	if f.unmarshal(data, values.Interface()) != nil {
+		return nil, true
+	}
+	object := make(map[string][]byte, values.Elem().Len())
+	for iter := values.Elem().MapRange(); iter.Next(); {
+		object[iter.Key().String()] = iter.Value().Bytes()
+	}
+	return object, true
+}
+
+// Returns the fields of typ in order by the name they are decoded from,
+// including the fields of embedded structs without a name of their own.
+func (f fieldPathFinder) fields(typ reflect.Type, fields []encodedValue) []encodedValue {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(lookupTag(field, f.tags), ",")[0]
+		if name == "-" || (field.PkgPath != "" && !field.Anonymous) {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct && !isTimeType(fieldType) {
+			fields = f.fields(fieldType, fields)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, encodedValue{name: name, typ: field.Type})
+	}
+	return fields
+}
+
+// Takes the size of data from the budget, reporting whether it was left.
+func spend(budget *int, data []byte) bool {
+	*budget -= len(data)
+	return *budget >= 0
+}
@@ -137,3 +137,24 @@ func unpackFileData(fh *multipart.FileHeader) string {
 
 	return fb.String()
 }
+
+func (s *fileSuite) Test_BracketNotation(c *C) {
+	blogPost := BlogPost{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "picture[]",
+			fileName:  "first.txt",
+			data:      "first",
+		},
+		fileInfo{
+			fieldName: "picture[]",
+			fileName:  "second.txt",
+			data:      "second",
+		},
+	})
+	MultipartForm.Bind(&blogPost, req)
+
+	c.Assert(blogPost.Pictures, HasLen, 2)
+	c.Assert(blogPost.Pictures[0].Filename, Equals, "first.txt")
+	c.Assert(blogPost.Pictures[1].Filename, Equals, "second.txt")
+}
@@ -2,7 +2,6 @@ package binding
 
 import (
 	"net/http"
-	"reflect"
 )
 
 type formBinding struct{}
@@ -11,6 +10,10 @@ func (_ formBinding) Name() string {
 	return "form"
 }
 
+func (_ formBinding) tags() []string {
+	return []string{"form"}
+}
+
 // Form is middleware to deserialize form-urlencoded data from the request.
 // It gets data from the form-urlencoded body, if present, or from the
 // query string. It uses the http.Request.ParseForm() method
@@ -20,22 +23,14 @@ func (_ formBinding) Name() string {
 // keys, for example: key=val1&key=val2&key=val3
 // An interface pointer can be added as a second argument in order
 // to map the struct to a specific interface.
-func (_ formBinding) Bind(dst interface{}, req *http.Request) error {
-
-	v := reflect.ValueOf(dst)
-	if v.Kind() != reflect.Ptr {
-		return ErrorInputNotByReference
-	}
-
-	//reset element to zero variant
-	v = v.Elem()
-	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
-		v.Set(reflect.New(v.Type().Elem()))
-	}
+func (b formBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
 
-	v = reflect.Indirect(v)
-	if v.Kind() != reflect.Struct || !v.CanSet() {
-		return ErrorInputIsNotStructure
+func (_ formBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
 	}
 
 	// Format validation of the request body or the URL would add considerable overhead,
@@ -44,7 +39,39 @@ func (_ formBinding) Bind(dst interface{}, req *http.Request) error {
 	// it is not in all cases a bad request, so let's return 422.
 	parseErr := req.ParseForm()
 	if parseErr != nil {
-		return ErrorDeserialization
+		return deserializationError(parseErr.Error(), parseErr)
+	}
+	mapForm("", v, newFormData("form", req.Form, nil), errs)
+	return nil
+}
+
+type formPostBinding struct{}
+
+func (_ formPostBinding) Name() string {
+	return "form-post"
+}
+
+func (_ formPostBinding) tags() []string {
+	return []string{"form"}
+}
+
+// FormPost deserializes the form-urlencoded body of the request like Form,
+// but only reads http.Request.PostForm, so values in the query string can
+// never end up in the struct. Use Query to bind the query string separately.
+func (b formPostBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ formPostBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
+	}
+
+	parseErr := req.ParseForm()
+	if parseErr != nil {
+		return deserializationError(parseErr.Error(), parseErr)
 	}
-	return mapForm("", v, req.Form, nil)
+	mapForm("", v, newFormData("form", req.PostForm, nil), errs)
+	return nil
 }
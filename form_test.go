@@ -1,6 +1,10 @@
 package binding
 
-import . "gopkg.in/check.v1"
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
 
 type formSuite struct{}
 
@@ -75,7 +79,7 @@ func (s *formSuite) Test_MalformedBody(c *C) {
 	err := Form.Bind(&post, req)
 
 	c.Assert(err, NotNil)
-	c.Assert(err, DeepEquals, ErrorDeserialization)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
 	c.Assert(post, DeepEquals, Post{})
 }
 
@@ -159,3 +163,233 @@ func (s *formSuite) Test_EmbedStructPointerPtr(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(embedPerson, DeepEquals, &EmbedPerson{&Person{Name: "Glorious Post Title", Email: "Lorem ipsum dolor sit amet"}})
 }
+
+type Library struct {
+	Books []BookShelf `form:"books"`
+}
+
+type BookShelf struct {
+	Readers []Person `form:"readers"`
+}
+
+func (s *formSuite) Test_NestedSlices(c *C) {
+	library := Library{}
+	req := newRequest(`POST`, ``, `books.1.readers.0.name=Matt+Holt&books.1.readers.1.name=Michael+Boke`, formContentType)
+	err := Form.Bind(&library, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(library, DeepEquals, Library{Books: []BookShelf{{}, {Readers: []Person{{Name: "Matt Holt"}, {Name: "Michael Boke"}}}}})
+}
+
+type Product struct {
+	Name     string             `form:"name"`
+	Attrs    map[string]string  `form:"attrs"`
+	Filters  map[string][]int   `form:"filters"`
+	Prices   map[int]float64    `form:"prices"`
+	Variants map[string]Person  `form:"variants"`
+	Owners   map[string]*Person `form:"owners"`
+	Missing  map[string]string  `form:"missing"`
+}
+
+func (s *formSuite) Test_Maps(c *C) {
+	product := Product{}
+	req := newRequest(`POST`, ``, `name=Shirt&attrs.color=red&attrs[size]=XL&filters.sizes=1&filters.sizes=2&filters[ids]=3&prices.1=9.95&prices[10]=89.50&variants.small.name=S&variants.small.email=s@test.com&owners.main.name=Matt+Holt`, formContentType)
+	err := Form.Bind(&product, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(product, DeepEquals, Product{
+		Name:     "Shirt",
+		Attrs:    map[string]string{"color": "red", "size": "XL"},
+		Filters:  map[string][]int{"sizes": {1, 2}, "ids": {3}},
+		Prices:   map[int]float64{1: 9.95, 10: 89.50},
+		Variants: map[string]Person{"small": {Name: "S", Email: "s@test.com"}},
+		Owners:   map[string]*Person{"main": {Name: "Matt Holt"}},
+	})
+}
+
+func (s *formSuite) Test_MapsKeepExistingEntries(c *C) {
+	product := Product{
+		Attrs:    map[string]string{"color": "blue", "fabric": "cotton"},
+		Variants: map[string]Person{"small": {Name: "S", Email: "s@test.com"}},
+	}
+	req := newRequest(`POST`, ``, `attrs.color=red&variants.small.name=Small`, formContentType)
+	err := Form.Bind(&product, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(product.Attrs, DeepEquals, map[string]string{"color": "red", "fabric": "cotton"})
+	c.Assert(product.Variants, DeepEquals, map[string]Person{"small": {Name: "Small", Email: "s@test.com"}})
+}
+
+func (s *formSuite) Test_MapsErrorStrict(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	product := Product{}
+	req := newRequest(`POST`, ``, `prices.one=1&prices.2=two&filters.ids=x`, formContentType)
+	err := Form.Bind(&product, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Field, Equals, "filters.ids")
+	c.Assert(errs[1].Field, Equals, "prices.2")
+	c.Assert(errs[2].Field, Equals, "prices.one")
+}
+
+func (s *formSuite) Test_BracketNotation(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&id=1&author[name]=Matt+Holt&coauthor[email]=other@test.com&rating[]=4&rating[]=3&readers[0][name]=Person+a&readers[1][name]=Person+b&contributors[1].name=Michael+Boke`, formContentType)
+	err := Form.Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost, DeepEquals, BlogPost{
+		Post:         Post{Title: "Glorious Post Title"},
+		Id:           1,
+		Author:       Person{Name: "Matt Holt"},
+		Coauthor:     &Person{Email: "other@test.com"},
+		Ratings:      []int{4, 3},
+		Readers:      []Person{{Name: "Person a"}, {Name: "Person b"}},
+		Contributors: []*Person{{}, {Name: "Michael Boke"}},
+	})
+}
+
+func (s *formSuite) Test_BracketNotationMaps(c *C) {
+	product := Product{}
+	req := newRequest(`POST`, ``, `owners[main][name]=Matt+Holt&filters[sizes][]=1&filters[sizes][]=2`, formContentType)
+	err := Form.Bind(&product, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(product, DeepEquals, Product{
+		Filters: map[string][]int{"sizes": {1, 2}},
+		Owners:  map[string]*Person{"main": {Name: "Matt Holt"}},
+	})
+}
+
+func (s *formSuite) Test_SliceIndexTooHigh(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&readers.0.name=Person+a&readers.99999999.name=x`, formContentType)
+	err := Form.Bind(&blogPost, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "readers.99999999")
+	c.Assert(errs[0].Classification, Equals, IndexError)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(blogPost, DeepEquals, BlogPost{Post: Post{Title: "Glorious Post Title"}, Readers: []Person{{Name: "Person a"}}})
+}
+
+func (s *formSuite) Test_SliceTooLong(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `readers.0.name=Person+a&readers.5000.name=x`, formContentType)
+	err := Form.Bind(&blogPost, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "readers")
+	c.Assert(errs[0].Classification, Equals, IndexError)
+	c.Assert(blogPost.Readers, HasLen, 0)
+}
+
+func (s *formSuite) Test_CompactSliceIndices(c *C) {
+	CompactSliceIndices = true
+	defer func() { CompactSliceIndices = false }()
+
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `readers.1.name=Person+a&readers.5.name=Person+b&readers.5000.name=Person+c&contributors.3.name=Michael+Boke`, formContentType)
+	err := Form.Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost, DeepEquals, BlogPost{
+		Readers:      []Person{{Name: "Person a"}, {Name: "Person b"}, {Name: "Person c"}},
+		Contributors: []*Person{{Name: "Michael Boke"}},
+	})
+}
+
+type Survey struct {
+	Tags    []string         `form:"tags"`
+	Ratings []int            `form:"ratings"`
+	Top     [3]int           `form:"top"`
+	Answers map[string][]int `form:"answers"`
+}
+
+func (s *formSuite) Test_IndexedPrimitiveSlices(c *C) {
+	repeated := Survey{}
+	req := newRequest(`POST`, ``, `tags=a&tags=b&ratings=4&ratings=5&top=7&top=8&top=9&answers.q1=1&answers.q1=2`, formContentType)
+	c.Assert(Form.Bind(&repeated, req), IsNil)
+
+	indexed := Survey{}
+	req = newRequest(`POST`, ``, `tags.1=b&tags.0=a&ratings[0]=4&ratings[1]=5&top.2=9&top.0=7&top.1=8&answers.q1.1=2&answers.q1.0=1`, formContentType)
+	c.Assert(Form.Bind(&indexed, req), IsNil)
+
+	expected := Survey{
+		Tags:    []string{"a", "b"},
+		Ratings: []int{4, 5},
+		Top:     [3]int{7, 8, 9},
+		Answers: map[string][]int{"q1": {1, 2}},
+	}
+	c.Assert(repeated, DeepEquals, expected)
+	c.Assert(indexed, DeepEquals, expected)
+}
+
+func (s *formSuite) Test_IndexedPrimitiveSlicesSparse(c *C) {
+	survey := Survey{}
+	req := newRequest(`POST`, ``, `tags.2=c&tags.0=a&top.1=8`, formContentType)
+	err := Form.Bind(&survey, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(survey, DeepEquals, Survey{Tags: []string{"a", "", "c"}, Top: [3]int{0, 8, 0}})
+
+	CompactSliceIndices = true
+	defer func() { CompactSliceIndices = false }()
+
+	survey = Survey{}
+	req = newRequest(`POST`, ``, `tags.2=c&tags.0=a&top.1=8`, formContentType)
+	err = Form.Bind(&survey, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(survey, DeepEquals, Survey{Tags: []string{"a", "c"}, Top: [3]int{8, 0, 0}})
+}
+
+func (s *formSuite) Test_ArrayOverflow(c *C) {
+	survey := Survey{}
+	req := newRequest(`POST`, ``, `top.0=1&top.3=4&ratings.20000=1`, formContentType)
+	err := Form.Bind(&survey, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "ratings.20000")
+	c.Assert(errs[0].Classification, Equals, IndexError)
+	c.Assert(errs[1].Field, Equals, "top.3")
+	c.Assert(errs[1].Classification, Equals, IndexError)
+	c.Assert(survey, DeepEquals, Survey{Top: [3]int{1, 0, 0}})
+
+	survey = Survey{}
+	req = newRequest(`POST`, ``, `top=1&top=2&top=3&top=4`, formContentType)
+	err = Form.Bind(&survey, req)
+
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "top")
+	c.Assert(survey, DeepEquals, Survey{Top: [3]int{1, 2, 3}})
+}
+
+func (s *formSuite) Test_FormPostIgnoresQuery(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `/?title=From+Query&content=Query`, `title=From+Body`, formContentType)
+	err := FormPost.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "From Body"})
+}
+
+func (s *formSuite) Test_FormPostHappyPath(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&content=Lorem+ipsum+dolor+sit+amet`, formContentType)
+	err := FormPost.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+}
@@ -0,0 +1,41 @@
+package binding
+
+import (
+	"net/http"
+)
+
+type headerBinding struct{}
+
+func (_ headerBinding) Name() string {
+	return "header"
+}
+
+func (_ headerBinding) tags() []string {
+	return []string{"header"}
+}
+
+// Header maps the request headers into the struct fields with a matching
+// header tag, like header:"X-Request-Id". Header names are matched case
+// insensitive and headers that occur multiple times can be bound to slices.
+// The values are converted the same way as with Form. Like with Path, only
+// the fields with a header tag are validated.
+func (b headerBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidatedSource(b, dst, req)
+}
+
+func (_ headerBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string][]string)
+	for _, name := range tagNames(v.Type(), "header") {
+		if value := req.Header.Values(name); len(value) > 0 {
+			values[name] = value
+		}
+	}
+
+	mapForm("", v, newFormData("header", values, nil), errs)
+	return nil
+}
@@ -0,0 +1,92 @@
+package binding
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	Tracing struct {
+		RequestId string `header:"X-Request-Id" validate:"Required"`
+	}
+
+	RequestHeaders struct {
+		Tracing
+		Locale    string   `header:"accept-language"`
+		Tenant    int      `header:"X-Tenant"`
+		Forwarded []string `header:"X-Forwarded-For"`
+		Missing   string   `header:"X-Missing"`
+	}
+)
+
+type headerSuite struct{}
+
+var _ = Suite(&headerSuite{})
+
+func (s *headerSuite) Test_NotAStruct(c *C) {
+	test := int(1)
+	req := newRequest(`GET`, ``, ``, ``)
+	err := Header.Bind(&test, req)
+
+	c.Assert(err, DeepEquals, ErrorInputIsNotStructure)
+}
+
+func (s *headerSuite) Test_HappyPath(c *C) {
+	headers := RequestHeaders{}
+	req := newRequest(`GET`, ``, ``, ``)
+	req.Header.Set("X-Request-Id", "abc-123")
+	req.Header.Set("Accept-Language", "nl-NL")
+	req.Header.Set("X-Tenant", "42")
+	req.Header.Add("X-Forwarded-For", "10.0.0.1")
+	req.Header.Add("X-Forwarded-For", "10.0.0.2")
+	err := Header.Bind(&headers, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(headers, DeepEquals, RequestHeaders{
+		Tracing:   Tracing{RequestId: "abc-123"},
+		Locale:    "nl-NL",
+		Tenant:    42,
+		Forwarded: []string{"10.0.0.1", "10.0.0.2"},
+	})
+}
+
+func (s *headerSuite) Test_Errors(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	headers := RequestHeaders{}
+	req := newRequest(`GET`, ``, ``, ``)
+	req.Header.Set("X-Tenant", "acme")
+	err := Header.Bind(&headers, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "X-Tenant")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(errs[1].Field, Equals, "X-Request-Id")
+	c.Assert(errs[1].Classification, Equals, RequiredError)
+}
+
+func (s *headerSuite) Test_ValidatesHeaderFieldsOnly(c *C) {
+	upload := struct {
+		Tracing
+		Name string `json:"name" validate:"Required"`
+	}{}
+	req := newRequest(`POST`, ``, ``, ``)
+	req.Header.Set("X-Request-Id", "abc-123")
+	err := Header.Bind(&upload, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(upload.RequestId, Equals, "abc-123")
+
+	req.Header.Del("X-Request-Id")
+	upload.RequestId = ""
+	err = Header.Bind(&upload, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "X-Request-Id")
+}
@@ -2,9 +2,12 @@ package binding
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+
+	"google.golang.org/protobuf/proto"
 )
 
 type jsonBinding struct{}
@@ -13,23 +16,53 @@ func (_ jsonBinding) Name() string {
 	return "json"
 }
 
+func (_ jsonBinding) tags() []string {
+	return []string{"json"}
+}
+
 // Json is middleware to deserialize a JSON payload from the request
-// into the struct that is passed in. The resulting struct is then
+// into the struct that is passed in. A proto.Message is decoded with the
+// protobuf JSON mapping instead. The resulting struct is then
 // validated, but no error handling is actually performed here.
 // An interface pointer can be added as a second argument in order
 // to map the struct to a specific interface.
-func (_ jsonBinding) Bind(dst interface{}, req *http.Request) error {
+func (b jsonBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ jsonBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return ErrorInputNotByReference
 	}
 
+	if msg, ok := dst.(proto.Message); ok {
+		return unmarshalProtoJSON(msg, req)
+	}
+
 	if req.Body != nil {
 		defer req.Body.Close()
-		err := json.NewDecoder(req.Body).Decode(dst)
+		err := json.NewDecoder(limitedBody(req)).Decode(dst)
 		if err != nil && err != io.EOF {
-			return ErrorDeserialization
+			return jsonErrors(err)
 		}
 	}
 	return nil
 }
+
+// Translates a decoder error into field errors, keeping the offending
+// field path or byte offset reported by encoding/json.
+func jsonErrors(err error) Errors {
+	switch e := err.(type) {
+	case *json.UnmarshalTypeError:
+		return Errors{&FieldError{
+			Field:          e.Field,
+			Classification: TypeError,
+			Message:        fmt.Sprintf("cannot use %s value as %s (offset %d)", e.Value, e.Type, e.Offset),
+			Err:            err,
+		}}
+	case *json.SyntaxError:
+		return deserializationError(fmt.Sprintf("%s (offset %d)", e.Error(), e.Offset), err)
+	}
+	return deserializationError(err.Error(), err)
+}
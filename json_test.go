@@ -1,6 +1,11 @@
 package binding
 
-import . "gopkg.in/check.v1"
+import (
+	"errors"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
 
 type jsonSuite struct{}
 
@@ -57,7 +62,7 @@ func (s *jsonSuite) Test_MalformedJson(c *C) {
 	err := JSON.Bind(&post, req)
 
 	c.Assert(err, NotNil)
-	c.Assert(err, DeepEquals, ErrorDeserialization)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
 	c.Assert(post, DeepEquals, Post{})
 }
 
@@ -96,3 +101,41 @@ func (s *jsonSuite) Test_SliceOfPosts(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(posts, DeepEquals, []Post{Post{Title: "First Post"}, Post{Title: "Second Post"}})
 }
+
+func (s *jsonSuite) Test_TypeMismatchReportsField(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `{"title":"Glorious Post Title", "author":{"name":42}}`, jsonContentType)
+	err := JSON.Bind(&blogPost, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "author.name")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *jsonSuite) Test_SyntaxErrorReportsOffset(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title":}`, jsonContentType)
+	err := JSON.Bind(&post, req)
+
+	var fieldErr *FieldError
+	c.Assert(errors.As(err, &fieldErr), Equals, true)
+	c.Assert(fieldErr.Field, Equals, "")
+	c.Assert(fieldErr.Classification, Equals, DeserializationError)
+	c.Assert(fieldErr.Message, Matches, `.*\(offset 10\)`)
+}
+
+func (s *jsonSuite) Test_MaxBodySize(c *C) {
+	MaxBodySize = 16
+	defer func() { MaxBodySize = int64(1024 * 1024 * 16) }()
+
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	err := JSON.Bind(&post, req)
+
+	var maxErr *http.MaxBytesError
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.As(err, &maxErr), Equals, true)
+}
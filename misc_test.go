@@ -1,6 +1,15 @@
 package binding
 
-import . "gopkg.in/check.v1"
+import (
+	"errors"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
 
 type Everything struct {
 	Integer    int     `form:"integer"`
@@ -58,3 +67,195 @@ func (s *miscSuite) Test_AllTypesError(c *C) {
 	c.Assert(errs, IsNil)
 	c.Assert(test, DeepEquals, Everything{})
 }
+
+func (s *miscSuite) Test_AllTypesErrorLenientReported(c *C) {
+	reported := Errors{}
+	OnConversionError = func(err *FieldError) {
+		reported = append(reported, err)
+	}
+	defer func() { OnConversionError = nil }()
+
+	test := Everything{}
+	req := newRequest(`POST`, ``, `integer=12&integer8=asdf&uinteger16=70000`, formContentType)
+	err := Form.Bind(&test, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(test, DeepEquals, Everything{Integer: 12})
+	c.Assert(reported, HasLen, 2)
+	c.Assert(reported[0].Field, Equals, "integer8")
+	c.Assert(reported[0].Classification, Equals, TypeError)
+	c.Assert(reported[0].Value, Equals, "asdf")
+	c.Assert(reported[1].Field, Equals, "uinteger16")
+	c.Assert(reported[1].Value, Equals, "70000")
+}
+
+func (s *miscSuite) Test_AllTypesErrorStrict(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	test := Everything{}
+	req := newRequest(`POST`, ``, `integer=&integer8=asdf&integer16=--&integer32=&integer64=dsf&uinteger=&uinteger8=asdf&uinteger16=+&uinteger32= 32 &uinteger64=+%20+&boolean_1=&boolean_2=asdf&fl32_1=asdf&fl32_2=&fl64_1=&fl64_2=asdfstr`, formContentType)
+	err := Form.Bind(&test, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 10)
+	for _, fieldErr := range errs {
+		c.Assert(fieldErr.Classification, Equals, TypeError)
+	}
+	c.Assert(errs[0].Field, Equals, "integer8")
+	c.Assert(errs[0].Value, Equals, "asdf")
+	c.Assert(errors.Is(err, strconv.ErrSyntax), Equals, true)
+	c.Assert(test, DeepEquals, Everything{})
+}
+
+func (s *miscSuite) Test_Overflow(c *C) {
+	test := Everything{}
+	req := newRequest(`POST`, ``, `integer8=300&uinteger16=70000&integer=1`, formContentType)
+	err := Form.Bind(&test, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(test, DeepEquals, Everything{Integer: 1})
+}
+
+func (s *miscSuite) Test_OverflowStrict(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	test := Everything{}
+	req := newRequest(`POST`, ``, `integer8=300&uinteger16=70000&integer=1`, formContentType)
+	err := Form.Bind(&test, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs.Has(TypeError), Equals, true)
+	c.Assert(errors.Is(err, strconv.ErrRange), Equals, true)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(test, DeepEquals, Everything{Integer: 1})
+}
+
+type Schedule struct {
+	Start     time.Time       `form:"start"`
+	End       *time.Time      `form:"end"`
+	Day       time.Time       `form:"day" time_format:"2006-01-02" time_location:"Europe/Amsterdam"`
+	Holidays  []time.Time     `form:"holiday" time_format:"2006-01-02"`
+	Timeout   time.Duration   `form:"timeout"`
+	Intervals []time.Duration `form:"interval"`
+	Invalid   time.Time       `form:"invalid" time_location:"Mars/Olympus_Mons"`
+	Untagged  time.Time
+}
+
+func (s *miscSuite) Test_Time(c *C) {
+	schedule := Schedule{}
+	req := newRequest(`POST`, ``, `start=2015-06-01T10:00:00%2B02:00&end=2015-06-02T12:30:00Z&day=2015-06-01&holiday=2015-12-25&holiday=2015-12-26&timeout=1m30s&interval=1s&interval=5ms&untagged=2015-06-01T10:00:00Z`, formContentType)
+	err := Form.Bind(&schedule, req)
+
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	c.Assert(err, IsNil)
+	c.Assert(schedule.Start.Equal(time.Date(2015, 6, 1, 8, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(schedule.End, NotNil)
+	c.Assert(schedule.End.Equal(time.Date(2015, 6, 2, 12, 30, 0, 0, time.UTC)), Equals, true)
+	c.Assert(schedule.Day, DeepEquals, time.Date(2015, 6, 1, 0, 0, 0, 0, amsterdam))
+	c.Assert(schedule.Holidays, DeepEquals, []time.Time{time.Date(2015, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2015, 12, 26, 0, 0, 0, 0, time.UTC)})
+	c.Assert(schedule.Timeout, Equals, 90*time.Second)
+	c.Assert(schedule.Intervals, DeepEquals, []time.Duration{time.Second, 5 * time.Millisecond})
+	c.Assert(schedule.Untagged.IsZero(), Equals, true)
+}
+
+func (s *miscSuite) Test_TimeEmpty(c *C) {
+	schedule := Schedule{}
+	req := newRequest(`POST`, ``, `start=&end=&timeout=`, formContentType)
+	err := Form.Bind(&schedule, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(schedule, DeepEquals, Schedule{})
+}
+
+func (s *miscSuite) Test_TimeErrorStrict(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	schedule := Schedule{}
+	req := newRequest(`POST`, ``, `start=yesterday&end=2015-06-02&day=01-06-2015&timeout=forever&invalid=2015-06-01T10:00:00Z`, formContentType)
+	err := Form.Bind(&schedule, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 5)
+	c.Assert(errs[0].Field, Equals, "start")
+	c.Assert(errs[0].Value, Equals, "yesterday")
+	c.Assert(errs[1].Field, Equals, "end")
+	c.Assert(errs[2].Field, Equals, "day")
+	c.Assert(errs[3].Field, Equals, "timeout")
+	c.Assert(errs[4].Field, Equals, "invalid")
+	c.Assert(schedule, DeepEquals, Schedule{})
+}
+
+type (
+	Level int
+
+	Tags []string
+
+	Server struct {
+		Address  net.IP   `form:"address"`
+		Mirrors  []net.IP `form:"mirror"`
+		Capacity *big.Int `form:"capacity"`
+		Level    Level    `form:"level"`
+		Levels   []Level  `form:"levels"`
+		Tags     Tags     `form:"tags"`
+	}
+)
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+func (t *Tags) UnmarshalForm(values []string) error {
+	for _, value := range values {
+		*t = append(*t, strings.Split(value, ",")...)
+	}
+	return nil
+}
+
+func (s *miscSuite) Test_Unmarshalers(c *C) {
+	server := Server{}
+	req := newRequest(`POST`, ``, `address=10.0.0.1&mirror=10.0.0.2&mirror=10.0.0.3&capacity=123456789012345678901234567890&level=high&levels=low&levels=high&tags=a,b&tags=c`, formContentType)
+	err := Form.Bind(&server, req)
+
+	capacity, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	c.Assert(err, IsNil)
+	c.Assert(server, DeepEquals, Server{
+		Address:  net.ParseIP("10.0.0.1"),
+		Mirrors:  []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")},
+		Capacity: capacity,
+		Level:    Level(2),
+		Levels:   []Level{1, 2},
+		Tags:     Tags{"a", "b", "c"},
+	})
+}
+
+func (s *miscSuite) Test_UnmarshalersErrorStrict(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	server := Server{}
+	req := newRequest(`POST`, ``, `address=10.0.0&capacity=lots&level=medium&capacity=`, formContentType)
+	err := Form.Bind(&server, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Field, Equals, "address")
+	c.Assert(errs[1].Field, Equals, "capacity")
+	c.Assert(errs[2].Field, Equals, "level")
+	c.Assert(errs[2].Message, Equals, `cannot convert "medium": unknown level`)
+}
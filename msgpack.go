@@ -0,0 +1,82 @@
+package binding
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type msgpackBinding struct{}
+
+func (_ msgpackBinding) Name() string {
+	return "msgpack"
+}
+
+func (_ msgpackBinding) tags() []string {
+	return []string{"msgpack", "json"}
+}
+
+// MsgPack deserializes a MessagePack payload from the request into the
+// struct that is passed in and validates the result. Fields are matched by
+// their msgpack tag, or their json tag when they have none, so structs
+// shared with the JSON binding need no extra tags.
+func (b msgpackBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ msgpackBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	if req.Body != nil {
+		defer req.Body.Close()
+		data, err := io.ReadAll(limitedBody(req))
+		if err != nil {
+			return deserializationError(err.Error(), err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		if err := msgpackUnmarshal(data, dst); err != nil {
+			return msgpackErrors(data, v.Type(), err)
+		}
+	}
+	return nil
+}
+
+var msgpackFieldPath = fieldPathFinder{
+	tags:      "msgpack,json",
+	unmarshal: msgpackUnmarshal,
+	raw:       reflect.TypeOf(msgpack.RawMessage{}),
+	unmarshalers: []reflect.Type{
+		reflect.TypeOf((*msgpack.CustomDecoder)(nil)).Elem(),
+		reflect.TypeOf((*msgpack.Unmarshaler)(nil)).Elem(),
+	},
+}
+
+func msgpackUnmarshal(data []byte, dst interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(dst)
+}
+
+// Translates a decoder error into field errors. The decoder does not tell
+// which value it could not convert, so the payload is decoded again value
+// by value to find the field, like jsonErrors reports it.
+func msgpackErrors(data []byte, typ reflect.Type, err error) Errors {
+	field := msgpackFieldPath.find(data, typ)
+	if field == "" {
+		return deserializationError(err.Error(), err)
+	}
+	return Errors{&FieldError{
+		Field:          field,
+		Classification: TypeError,
+		Message:        err.Error(),
+		Err:            err,
+	}}
+}
@@ -0,0 +1,158 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	. "gopkg.in/check.v1"
+)
+
+type (
+	Telemetry struct {
+		Device  string  `msgpack:"dev" json:"device" validate:"Required"`
+		Battery int     `json:"battery" validate:"Range(0,100)"`
+		Samples []int   `json:"samples"`
+		Ratio   float64 `msgpack:"ratio"`
+	}
+
+	TelemetryBatch struct {
+		Readings []Telemetry `json:"readings"`
+	}
+
+	TelemetryTree struct {
+		Children []TelemetryTree `json:"children"`
+	}
+)
+
+type msgpackSuite struct{}
+
+var _ = Suite(&msgpackSuite{})
+
+func (s *msgpackSuite) Test_NotByReference(c *C) {
+	telemetry := Telemetry{}
+	err := MsgPack.Bind(telemetry, newMsgPackRequest(map[string]interface{}{"dev": "phone"}))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *msgpackSuite) Test_HappyPath(c *C) {
+	telemetry := Telemetry{}
+	req := newMsgPackRequest(map[string]interface{}{"dev": "phone", "battery": 80, "samples": []int{1, 2}, "ratio": 0.5})
+	err := MsgPack.Bind(&telemetry, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(telemetry, DeepEquals, Telemetry{Device: "phone", Battery: 80, Samples: []int{1, 2}, Ratio: 0.5})
+}
+
+func (s *msgpackSuite) Test_EmptyPayload(c *C) {
+	telemetry := Telemetry{Device: "phone"}
+	err := MsgPack.Bind(&telemetry, newRequest(`POST`, ``, ``, MIMEMsgPack))
+
+	c.Assert(err, IsNil)
+	c.Assert(telemetry, DeepEquals, Telemetry{Device: "phone"})
+}
+
+func (s *msgpackSuite) Test_Validation(c *C) {
+	telemetry := Telemetry{}
+	err := MsgPack.Bind(&telemetry, newMsgPackRequest(map[string]interface{}{"battery": 120}))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "dev")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+	c.Assert(errs[1].Field, Equals, "battery")
+	c.Assert(errs[1].Classification, Equals, RangeError)
+}
+
+func (s *msgpackSuite) Test_TypeMismatch(c *C) {
+	telemetry := Telemetry{}
+	err := MsgPack.Bind(&telemetry, newMsgPackRequest(map[string]interface{}{"dev": "phone", "battery": "full"}))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "battery")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *msgpackSuite) Test_NestedTypeMismatch(c *C) {
+	batch := TelemetryBatch{}
+	err := MsgPack.Bind(&batch, newMsgPackRequest(map[string]interface{}{
+		"readings": []interface{}{
+			map[string]interface{}{"dev": "phone"},
+			map[string]interface{}{"dev": "watch", "samples": []interface{}{1, "two"}},
+		},
+	}))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "readings.1.samples.1")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+}
+
+func (s *msgpackSuite) Test_DeeplyNestedTypeMismatch(c *C) {
+	var payload interface{} = "leaf"
+	for i := 0; i < 2000; i++ {
+		payload = map[string]interface{}{"children": []interface{}{payload}}
+	}
+
+	start := time.Now()
+	tree := TelemetryTree{}
+	err := MsgPack.Bind(&tree, newMsgPackRequest(payload))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "")
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+	c.Assert(time.Since(start) < time.Second, Equals, true)
+}
+
+func (s *msgpackSuite) Test_MalformedPayload(c *C) {
+	telemetry := Telemetry{}
+	err := MsgPack.Bind(&telemetry, newRequest(`POST`, ``, "\x82\xa3dev", MIMEMsgPack))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs[0].Field, Equals, "")
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+}
+
+func (s *msgpackSuite) Test_MaxBodySize(c *C) {
+	MaxBodySize = 16
+	defer func() { MaxBodySize = int64(1024 * 1024 * 16) }()
+
+	telemetry := Telemetry{}
+	err := MsgPack.Bind(&telemetry, newMsgPackRequest(map[string]interface{}{"dev": strings.Repeat("p", 32)}))
+
+	var maxErr *http.MaxBytesError
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.As(err, &maxErr), Equals, true)
+}
+
+func (s *msgpackSuite) Test_Bind(c *C) {
+	for _, contentType := range []string{MIMEMsgPack, MIMEMsgPack2} {
+		telemetry := Telemetry{}
+		req := newMsgPackRequest(map[string]interface{}{"dev": "phone"})
+		req.Header.Set("Content-Type", contentType)
+		err := Bind(&telemetry, req)
+
+		c.Assert(err, IsNil, Commentf(contentType))
+		c.Assert(telemetry.Device, Equals, "phone")
+	}
+}
+
+func newMsgPackRequest(payload interface{}) *http.Request {
+	var buf bytes.Buffer
+	if err := msgpack.NewEncoder(&buf).Encode(payload); err != nil {
+		panic(err)
+	}
+	return newRequest(`POST`, ``, buf.String(), MIMEMsgPack)
+}
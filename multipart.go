@@ -2,7 +2,6 @@ package binding
 
 import (
 	"net/http"
-	"reflect"
 )
 
 type multipartBinding struct{}
@@ -11,26 +10,22 @@ func (_ multipartBinding) Name() string {
 	return "multipart"
 }
 
+func (_ multipartBinding) tags() []string {
+	return []string{"form"}
+}
+
 // MultipartForm works much like Form, except it can parse multipart forms
 // and handle file uploads. Like the other deserialization middleware handlers,
 // you can pass in an interface to make the interface available for injection
 // into other handlers later.
-func (_ multipartBinding) Bind(dst interface{}, req *http.Request) error {
-
-	v := reflect.ValueOf(dst)
-	if v.Kind() != reflect.Ptr {
-		return ErrorInputNotByReference
-	}
-
-	//reset element to zero variant
-	v = v.Elem()
-	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
-		v.Set(reflect.New(v.Type().Elem()))
-	}
+func (b multipartBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
 
-	v = reflect.Indirect(v)
-	if v.Kind() != reflect.Struct || !v.CanSet() {
-		return ErrorInputIsNotStructure
+func (_ multipartBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
 	}
 
 	// This if check is necessary due to https://github.com/martini-contrib/csrf/issues/6
@@ -39,15 +34,16 @@ func (_ multipartBinding) Bind(dst interface{}, req *http.Request) error {
 		// when content is not multipart; see https://code.google.com/p/go/issues/detail?id=6334
 		if multipartReader, err := req.MultipartReader(); err != nil {
 			// TODO: Cover this and the next error check with tests
-			return ErrorDeserialization
+			return deserializationError(err.Error(), err)
 		} else {
 			form, parseErr := multipartReader.ReadForm(MaxMemory)
 			if parseErr != nil {
-				return ErrorDeserialization
+				return deserializationError(parseErr.Error(), parseErr)
 			}
 			req.MultipartForm = form
 		}
 	}
 
-	return mapForm("", v, req.MultipartForm.Value, req.MultipartForm.File)
+	mapForm("", v, newFormData("form", req.MultipartForm.Value, req.MultipartForm.File), errs)
+	return nil
 }
@@ -2,6 +2,7 @@ package binding
 
 import (
 	"bytes"
+	"errors"
 	"mime/multipart"
 	"net/http"
 	"strconv"
@@ -106,7 +107,7 @@ func (s *multipartSuite) Test_BadEncoding(c *C) {
 	response := BlogPost{}
 	err := MultipartForm.Bind(&response, req)
 
-	c.Assert(err, DeepEquals, ErrorDeserialization)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
 	c.Assert(response, DeepEquals, BlogPost{})
 }
 
@@ -0,0 +1,133 @@
+package binding
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type ndjsonBinding struct{}
+
+func (_ ndjsonBinding) Name() string {
+	return "ndjson"
+}
+
+// NDJSON streams a newline delimited JSON (JSON Lines) body record by
+// record, without reading the whole body in memory. dst receives every
+// record that decodes and validates, and is either a callback func(T) or
+// func(T) error, or a channel of T. A callback that returns an error stops
+// the binding with that error. The channel is not closed, and sending on it
+// stops when the request context is done.
+//
+// Records that fail are skipped and reported once the body is read, with
+// the index of the record in the field path (like 3.name) and the line
+// number in the message. Blank lines are ignored and null records fail. The
+// binding stops early at a line that exceeds MaxLineSize, or at the next
+// record that fails once MaxRecordErrors records have failed.
+func (_ ndjsonBinding) Bind(dst interface{}, req *http.Request) error {
+	deliver, elemType, err := ndjsonReceiver(dst, req)
+	if err != nil {
+		return err
+	}
+	if req.Body == nil {
+		return nil
+	}
+	defer req.Body.Close()
+
+	errs := Errors{}
+	failed := 0
+	scanner := bufio.NewScanner(limitedBody(req))
+	scanner.Buffer(nil, MaxLineSize)
+	line, index := 0, 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		elem := reflect.New(elemType)
+		recordErrs := Errors{}
+		if bytes.Equal(data, []byte("null")) {
+			recordErrs = deserializationError("record is null", nil)
+		} else if err := json.Unmarshal(data, elem.Interface()); err != nil {
+			recordErrs = jsonErrors(err)
+		} else {
+			validate(&recordErrs, elem, req, "json")
+		}
+
+		if len(recordErrs) == 0 {
+			if err := deliver(elem.Elem()); err != nil {
+				return err
+			}
+		} else if failed == MaxRecordErrors {
+			errs.Add("", DeserializationError, fmt.Sprintf("line %d: stopped after %d failed records", line, failed))
+			return errs
+		} else {
+			failed++
+		}
+		errs = append(errs, ndjsonErrors(recordErrs, line, index)...)
+		index++
+	}
+
+	if err := scanner.Err(); err == bufio.ErrTooLong {
+		message := fmt.Sprintf("line exceeds %d bytes", MaxLineSize)
+		errs = append(errs, ndjsonErrors(deserializationError(message, err), line+1, index)...)
+	} else if err != nil {
+		errs = append(errs, deserializationError(err.Error(), err)...)
+	}
+	return errs.errorOrNil()
+}
+
+// Relates the errors of a record to its index and line.
+func ndjsonErrors(recordErrs Errors, line, index int) Errors {
+	errs := make(Errors, 0, len(recordErrs))
+	for _, fieldErr := range recordErrs {
+		fieldErr = prefixFieldError(fieldErr, strconv.Itoa(index)+".")
+		fieldErr.Message = fmt.Sprintf("line %d: %s", line, fieldErr.Message)
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
+
+// Returns the function delivering a record to dst, and the type of the
+// records dst receives.
+func ndjsonReceiver(dst interface{}, req *http.Request) (func(reflect.Value) error, reflect.Type, error) {
+	if dst == nil {
+		return nil, nil, ErrorInputIsNotStream
+	}
+
+	v := reflect.ValueOf(dst)
+	if (v.Kind() == reflect.Func || v.Kind() == reflect.Chan) && v.IsNil() {
+		return nil, nil, ErrorInputIsNotStream
+	}
+	switch typ := v.Type(); {
+	case typ.Kind() == reflect.Func && typ.NumIn() == 1 && !typ.IsVariadic() &&
+		(typ.NumOut() == 0 || (typ.NumOut() == 1 && typ.Out(0) == errorType)):
+		return func(elem reflect.Value) error {
+			if out := v.Call([]reflect.Value{elem}); len(out) == 1 && !out[0].IsNil() {
+				return out[0].Interface().(error)
+			}
+			return nil
+		}, typ.In(0), nil
+	case typ.Kind() == reflect.Chan && typ.ChanDir()&reflect.SendDir != 0:
+		done := reflect.ValueOf(req.Context().Done())
+		return func(elem reflect.Value) error {
+			chosen, _, _ := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: v, Send: elem},
+				{Dir: reflect.SelectRecv, Chan: done},
+			})
+			if chosen == 1 {
+				return req.Context().Err()
+			}
+			return nil
+		}, typ.Elem(), nil
+	}
+	return nil, nil, ErrorInputIsNotStream
+}
@@ -0,0 +1,197 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type Event struct {
+	Kind  string `json:"kind" validate:"Required"`
+	Value int    `json:"value"`
+}
+
+type ndjsonSuite struct{}
+
+var _ = Suite(&ndjsonSuite{})
+
+func (s *ndjsonSuite) Test_NotAStream(c *C) {
+	events := []Event{}
+	err := NDJSON.Bind(&events, newRequest(`POST`, ``, `{"kind":"click"}`, MIMENDJSON))
+	c.Assert(err, Equals, ErrorInputIsNotStream)
+
+	err = NDJSON.Bind(nil, newRequest(`POST`, ``, `{"kind":"click"}`, MIMENDJSON))
+	c.Assert(err, Equals, ErrorInputIsNotStream)
+
+	err = NDJSON.Bind(func(Event) int { return 0 }, newRequest(`POST`, ``, `{"kind":"click"}`, MIMENDJSON))
+	c.Assert(err, Equals, ErrorInputIsNotStream)
+
+	var callback func(Event)
+	err = NDJSON.Bind(callback, newRequest(`POST`, ``, `{"kind":"click"}`, MIMENDJSON))
+	c.Assert(err, Equals, ErrorInputIsNotStream)
+
+	var channel chan Event
+	err = NDJSON.Bind(channel, newRequest(`POST`, ``, `{"kind":"click"}`, MIMENDJSON))
+	c.Assert(err, Equals, ErrorInputIsNotStream)
+}
+
+func (s *ndjsonSuite) Test_Callback(c *C) {
+	payload := "{\"kind\":\"click\",\"value\":1}\n\n  {\"kind\":\"view\",\"value\":2}\r\n{\"kind\":\"scroll\"}"
+
+	events := []Event{}
+	err := NDJSON.Bind(func(e Event) {
+		events = append(events, e)
+	}, newRequest(`POST`, ``, payload, MIMENDJSON))
+
+	c.Assert(err, IsNil)
+	c.Assert(events, DeepEquals, []Event{{Kind: "click", Value: 1}, {Kind: "view", Value: 2}, {Kind: "scroll"}})
+}
+
+func (s *ndjsonSuite) Test_PointerCallback(c *C) {
+	events := []*Event{}
+	err := NDJSON.Bind(func(e *Event) error {
+		events = append(events, e)
+		return nil
+	}, newRequest(`POST`, ``, "{\"kind\":\"click\"}\n", MIMEJSONL))
+
+	c.Assert(err, IsNil)
+	c.Assert(events, DeepEquals, []*Event{{Kind: "click"}})
+}
+
+func (s *ndjsonSuite) Test_CallbackErrorStops(c *C) {
+	stop := errors.New("stop")
+	count := 0
+	err := NDJSON.Bind(func(e Event) error {
+		count++
+		return stop
+	}, newRequest(`POST`, ``, "{\"kind\":\"click\"}\n{\"kind\":\"view\"}\n", MIMENDJSON))
+
+	c.Assert(err, Equals, stop)
+	c.Assert(count, Equals, 1)
+}
+
+func (s *ndjsonSuite) Test_Channel(c *C) {
+	events := make(chan Event, 2)
+	err := NDJSON.Bind(events, newRequest(`POST`, ``, "{\"kind\":\"click\"}\n{\"kind\":\"view\"}\n", MIMENDJSON))
+	close(events)
+
+	c.Assert(err, IsNil)
+	received := []Event{}
+	for e := range events {
+		received = append(received, e)
+	}
+	c.Assert(received, DeepEquals, []Event{{Kind: "click"}, {Kind: "view"}})
+}
+
+func (s *ndjsonSuite) Test_ChannelStopsWithContext(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	events := make(chan Event)
+	req := newRequest(`POST`, ``, "{\"kind\":\"click\"}\n", MIMENDJSON).WithContext(ctx)
+	err := NDJSON.Bind((chan<- Event)(events), req)
+
+	c.Assert(err, Equals, context.Canceled)
+}
+
+func (s *ndjsonSuite) Test_LineErrors(c *C) {
+	payload := "{\"kind\":\"click\"}\n\n{\"kind\":\"view\",\"value\":\"high\"}\n{\"value\":3}\n{\"kind\":\n{\"kind\":\"scroll\"}\n"
+
+	events := []Event{}
+	err := NDJSON.Bind(func(e Event) {
+		events = append(events, e)
+	}, newRequest(`POST`, ``, payload, MIMENDJSON))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Field, Equals, "1.value")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(errs[0].Message, Matches, "line 3: .*")
+	c.Assert(errs[1].Field, Equals, "2.kind")
+	c.Assert(errs[1].Classification, Equals, RequiredError)
+	c.Assert(errs[1].Message, Matches, "line 4: .*")
+	c.Assert(errs[2].Field, Equals, "3")
+	c.Assert(errs[2].Classification, Equals, DeserializationError)
+	c.Assert(errs[2].Message, Matches, "line 5: .*")
+	c.Assert(events, DeepEquals, []Event{{Kind: "click"}, {Kind: "scroll"}})
+}
+
+func (s *ndjsonSuite) Test_NullRecord(c *C) {
+	events := []*Event{}
+	err := NDJSON.Bind(func(e *Event) {
+		events = append(events, e)
+	}, newRequest(`POST`, ``, "{\"kind\":\"click\"}\nnull\n", MIMENDJSON))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "1")
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+	c.Assert(errs[0].Message, Equals, "line 2: record is null")
+	c.Assert(events, DeepEquals, []*Event{{Kind: "click"}})
+}
+
+func (s *ndjsonSuite) Test_MaxLineSize(c *C) {
+	MaxLineSize = 32
+	defer func() { MaxLineSize = 1024 * 1024 }()
+
+	payload := "{\"kind\":\"click\"}\n{\"kind\":\"" + strings.Repeat("x", 32) + "\"}\n{\"kind\":\"view\"}\n"
+	events := []Event{}
+	err := NDJSON.Bind(func(e Event) {
+		events = append(events, e)
+	}, newRequest(`POST`, ``, payload, MIMENDJSON))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "1")
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+	c.Assert(errs[0].Message, Equals, "line 2: line exceeds 32 bytes")
+	c.Assert(events, DeepEquals, []Event{{Kind: "click"}})
+}
+
+func (s *ndjsonSuite) Test_MaxRecordErrors(c *C) {
+	MaxRecordErrors = 2
+	defer func() { MaxRecordErrors = 100 }()
+
+	payload := "{}\n{}\n{\"kind\":\"click\"}\n{}\n{\"kind\":\"view\"}\n"
+	events := []Event{}
+	err := NDJSON.Bind(func(e Event) {
+		events = append(events, e)
+	}, newRequest(`POST`, ``, payload, MIMENDJSON))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Field, Equals, "0.kind")
+	c.Assert(errs[1].Field, Equals, "1.kind")
+	c.Assert(errs[2].Field, Equals, "")
+	c.Assert(errs[2].Classification, Equals, DeserializationError)
+	c.Assert(errs[2].Message, Equals, "line 4: stopped after 2 failed records")
+	c.Assert(events, DeepEquals, []Event{{Kind: "click"}})
+}
+
+func (s *ndjsonSuite) Test_MaxBodySize(c *C) {
+	MaxBodySize = 16
+	defer func() { MaxBodySize = int64(1024 * 1024 * 16) }()
+
+	err := NDJSON.Bind(func(e Event) {}, newRequest(`POST`, ``, "{\"kind\":\"click\"}\n{\"kind\":\"view\"}\n", MIMENDJSON))
+
+	var maxErr *http.MaxBytesError
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.As(err, &maxErr), Equals, true)
+}
+
+func (s *ndjsonSuite) Test_Bind(c *C) {
+	events := []Event{}
+	err := Bind(func(e Event) {
+		events = append(events, e)
+	}, newRequest(`POST`, ``, "{\"kind\":\"click\"}\n", `application/jsonl; charset=utf-8`))
+
+	c.Assert(err, IsNil)
+	c.Assert(events, DeepEquals, []Event{{Kind: "click"}})
+}
@@ -0,0 +1,55 @@
+package binding
+
+import (
+	"net/http"
+)
+
+// PathParamsFunc returns the value of the named path parameter of the
+// request, or an empty string when the parameter does not exist.
+type PathParamsFunc func(req *http.Request, name string) string
+
+// PathParams looks up the path parameters for the Path binding. It defaults
+// to http.Request.PathValue, as filled by the http.ServeMux patterns of
+// Go 1.22. Replace it to read the parameters of another router, e.g. for
+// gorilla/mux:
+//
+//	binding.PathParams = func(req *http.Request, name string) string {
+//		return mux.Vars(req)[name]
+//	}
+var PathParams PathParamsFunc = (*http.Request).PathValue
+
+type pathBinding struct{}
+
+func (_ pathBinding) Name() string {
+	return "path"
+}
+
+func (_ pathBinding) tags() []string {
+	return []string{"path"}
+}
+
+// Path maps the parameters of the request path, like the id of
+// /users/{id}, into the struct fields with a matching path tag. The
+// values are converted the same way as with Form. Only the fields with a
+// path tag are validated, so the struct can hold fields bound from the
+// body as well; Validator implementations are left to the body binding.
+func (b pathBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidatedSource(b, dst, req)
+}
+
+func (_ pathBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string][]string)
+	for _, name := range tagNames(v.Type(), "path") {
+		if value := PathParams(req, name); value != "" {
+			values[name] = []string{value}
+		}
+	}
+
+	mapForm("", v, newFormData("path", values, nil), errs)
+	return nil
+}
@@ -0,0 +1,122 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	UserParams struct {
+		Id      int    `path:"id" validate:"Required"`
+		Section string `path:"section" validate:"In(profile,settings)"`
+	}
+
+	ProjectParams struct {
+		UserParams
+		Project string `path:"project"`
+		Unknown string `path:"unknown"`
+	}
+
+	// Mixes path parameters with fields bound from the body
+	RenameUser struct {
+		Id   int    `path:"id" validate:"Required"`
+		Name string `json:"name" validate:"Required"`
+	}
+)
+
+type pathSuite struct{}
+
+var _ = Suite(&pathSuite{})
+
+func (s *pathSuite) Test_NotByReference(c *C) {
+	params := UserParams{}
+	err := Path.Bind(params, newPathRequest("/users/{id}", "/users/1"))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *pathSuite) Test_HappyPath(c *C) {
+	params := ProjectParams{}
+	err := Path.Bind(&params, newPathRequest("/users/{id}/{section}/{project}", "/users/42/settings/binding"))
+
+	c.Assert(err, IsNil)
+	c.Assert(params, DeepEquals, ProjectParams{UserParams: UserParams{Id: 42, Section: "settings"}, Project: "binding"})
+}
+
+func (s *pathSuite) Test_Validation(c *C) {
+	params := UserParams{}
+	err := Path.Bind(&params, newPathRequest("/users/{id}/{section}", "/users/0/friends"))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "id")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+	c.Assert(errs[1].Field, Equals, "section")
+	c.Assert(errs[1].Classification, Equals, InError)
+}
+
+func (s *pathSuite) Test_ValidatesPathFieldsOnly(c *C) {
+	rename := RenameUser{}
+	err := Path.Bind(&rename, newPathRequest("/users/{id}", "/users/42"))
+
+	c.Assert(err, IsNil)
+	c.Assert(rename, DeepEquals, RenameUser{Id: 42})
+
+	err = Path.Bind(&rename, newPathRequest("/users/{id}", "/users/0"))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "id")
+}
+
+func (s *pathSuite) Test_ConversionErrorStrict(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	params := UserParams{}
+	err := Path.Bind(&params, newPathRequest("/users/{id}", "/users/abc"))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "id")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(errs[0].Value, Equals, "abc")
+}
+
+func (s *pathSuite) Test_CustomPathParams(c *C) {
+	PathParams = func(req *http.Request, name string) string {
+		return map[string]string{"id": "7", "section": "profile"}[name]
+	}
+	defer func() { PathParams = (*http.Request).PathValue }()
+
+	params := UserParams{}
+	req, _ := http.NewRequest("GET", "/users/7/profile", nil)
+	err := Path.Bind(&params, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(params, DeepEquals, UserParams{Id: 7, Section: "profile"})
+}
+
+// Routes the request through a ServeMux so the path values are set
+func newPathRequest(pattern, path string) *http.Request {
+	var routed *http.Request
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, func(_ http.ResponseWriter, req *http.Request) {
+		routed = req
+	})
+
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		panic(err)
+	}
+	mux.ServeHTTP(nil, req)
+	if routed == nil {
+		panic("pattern " + pattern + " does not match " + path)
+	}
+	return routed
+}
@@ -0,0 +1,73 @@
+package binding
+
+import (
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type protobufBinding struct{}
+
+func (_ protobufBinding) Name() string {
+	return "protobuf"
+}
+
+func (_ protobufBinding) tags() []string {
+	return []string{"json"}
+}
+
+// ProtoBuf deserializes a Protocol Buffers payload from the request into
+// the proto.Message that is passed in, and validates the result. Requests
+// with a JSON content type are decoded with the protobuf JSON mapping.
+// Targets that are not a proto.Message fail with ErrorInputIsNotProtoMessage.
+func (b protobufBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ protobufBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	msg, ok := dst.(proto.Message)
+	if !ok {
+		return ErrorInputIsNotProtoMessage
+	}
+
+	if hasMediaType(req.Header.Get("Content-Type"), MIMEJSON) {
+		return unmarshalProtoJSON(msg, req)
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return deserializationError(err.Error(), err)
+	}
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return deserializationError(err.Error(), err)
+	}
+	return nil
+}
+
+// Decodes the protobuf JSON mapping of msg from the request body. Like the
+// JSON binding, unknown fields are ignored and an empty body is accepted.
+func unmarshalProtoJSON(msg proto.Message, req *http.Request) error {
+	body, err := readBody(req)
+	if err != nil {
+		return deserializationError(err.Error(), err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, msg); err != nil {
+		return deserializationError(err.Error(), err)
+	}
+	return nil
+}
+
+// Reads and closes the request body, which may be absent, up to
+// MaxBodySize bytes.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(limitedBody(req))
+}
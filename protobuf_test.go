@@ -0,0 +1,81 @@
+package binding
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	. "gopkg.in/check.v1"
+)
+
+type protobufSuite struct{}
+
+var _ = Suite(&protobufSuite{})
+
+func (s *protobufSuite) Test_NotAProtoMessage(c *C) {
+	post := Post{}
+	err := ProtoBuf.Bind(&post, newRequest(`POST`, ``, ``, MIMEProtoBuf))
+
+	c.Assert(err, Equals, ErrorInputIsNotProtoMessage)
+}
+
+func (s *protobufSuite) Test_HappyPath(c *C) {
+	payload, _ := proto.Marshal(wrapperspb.String("Glorious Post Title"))
+
+	msg := &wrapperspb.StringValue{}
+	err := ProtoBuf.Bind(msg, newRequest(`POST`, ``, string(payload), MIMEProtoBuf))
+
+	c.Assert(err, IsNil)
+	c.Assert(msg.GetValue(), Equals, "Glorious Post Title")
+}
+
+func (s *protobufSuite) Test_EmptyPayload(c *C) {
+	msg := &wrapperspb.Int64Value{}
+	err := ProtoBuf.Bind(msg, newRequest(`POST`, ``, `-nil-`, MIMEProtoBuf))
+
+	c.Assert(err, IsNil)
+	c.Assert(msg.GetValue(), Equals, int64(0))
+}
+
+func (s *protobufSuite) Test_MalformedPayload(c *C) {
+	msg := &wrapperspb.StringValue{}
+	err := ProtoBuf.Bind(msg, newRequest(`POST`, ``, "\x0a\xff", MIMEProtoBuf))
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *protobufSuite) Test_ProtoJSON(c *C) {
+	msg := &structpb.Struct{}
+	err := ProtoBuf.Bind(msg, newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(msg.AsMap(), DeepEquals, map[string]interface{}{"title": "Glorious Post Title"})
+}
+
+func (s *protobufSuite) Test_ProtoJSONThroughJSONBinding(c *C) {
+	msg := &wrapperspb.Int64Value{}
+	err := Bind(msg, newRequest(`POST`, ``, `"42"`, jsonContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(msg.GetValue(), Equals, int64(42))
+}
+
+func (s *protobufSuite) Test_MalformedProtoJSON(c *C) {
+	msg := &wrapperspb.Int64Value{}
+	err := JSON.Bind(msg, newRequest(`POST`, ``, `"many"`, jsonContentType))
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *protobufSuite) Test_Bind(c *C) {
+	payload, _ := proto.Marshal(wrapperspb.Bool(true))
+
+	for _, contentType := range []string{MIMEProtoBuf, MIMEProtoBuf2} {
+		msg := &wrapperspb.BoolValue{}
+		err := Bind(msg, newRequest(`POST`, ``, string(payload), contentType))
+
+		c.Assert(err, IsNil, Commentf(contentType))
+		c.Assert(msg.GetValue(), Equals, true)
+	}
+}
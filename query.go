@@ -0,0 +1,33 @@
+package binding
+
+import (
+	"net/http"
+)
+
+type queryBinding struct{}
+
+func (_ queryBinding) Name() string {
+	return "query"
+}
+
+func (_ queryBinding) tags() []string {
+	return []string{"query"}
+}
+
+// Query maps the parameters of the URL query string into the struct fields
+// with a matching query tag. Unlike Form it never reads the request body.
+// Like with Path only the fields with a query tag are validated, so the
+// struct can hold fields bound from the body as well.
+func (b queryBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidatedSource(b, dst, req)
+}
+
+func (_ queryBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v, err := structOf(dst)
+	if err != nil {
+		return err
+	}
+
+	mapForm("", v, newFormData("query", req.URL.Query(), nil), errs)
+	return nil
+}
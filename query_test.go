@@ -0,0 +1,89 @@
+package binding
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type Pagination struct {
+	Page    int      `query:"page" validate:"Range(1,100)"`
+	Sort    string   `query:"sort"`
+	Filters []string `query:"filter"`
+	Title   string   `form:"title"`
+}
+
+type SearchOrders struct {
+	Page   int               `query:"page"`
+	Labels map[string]string `query:"labels"`
+	Attrs  map[string]string `json:"attrs"`
+	Items  []Post            `json:"items"`
+	Owner  *Person           `json:"owner"`
+}
+
+type ListComments struct {
+	Page  int    `query:"page" validate:"Required"`
+	Title string `json:"title" validate:"Required"`
+}
+
+type querySuite struct{}
+
+var _ = Suite(&querySuite{})
+
+func (s *querySuite) Test_NotByReference(c *C) {
+	page := Pagination{}
+	err := Query.Bind(page, newRequest(`GET`, `/?page=1`, ``, ``))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *querySuite) Test_HappyPath(c *C) {
+	page := Pagination{}
+	err := Query.Bind(&page, newRequest(`GET`, `/?page=2&sort=title&filter=a&filter=b`, ``, ``))
+
+	c.Assert(err, IsNil)
+	c.Assert(page, DeepEquals, Pagination{Page: 2, Sort: "title", Filters: []string{"a", "b"}})
+}
+
+func (s *querySuite) Test_IgnoresBody(c *C) {
+	page := Pagination{}
+	req := newRequest(`POST`, `/?page=2`, `page=3&sort=title&title=Post`, formContentType)
+	err := Query.Bind(&page, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(page, DeepEquals, Pagination{Page: 2})
+}
+
+func (s *querySuite) Test_Validation(c *C) {
+	page := Pagination{}
+	err := Query.Bind(&page, newRequest(`GET`, `/?page=500`, ``, ``))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "page")
+	c.Assert(errs[0].Classification, Equals, RangeError)
+}
+
+func (s *querySuite) Test_IgnoresUntaggedCollections(c *C) {
+	orders := SearchOrders{}
+	err := Query.Bind(&orders, newRequest(`GET`, `/?page=2&labels.env=prod&attrs.admin=true&items.50.title=x&owner.name=ada`, ``, ``))
+
+	c.Assert(err, IsNil)
+	c.Assert(orders, DeepEquals, SearchOrders{Page: 2, Labels: map[string]string{"env": "prod"}})
+}
+
+func (s *querySuite) Test_ValidatesQueryFieldsOnly(c *C) {
+	comments := ListComments{}
+	err := Query.Bind(&comments, newRequest(`GET`, `/?page=1`, ``, ``))
+
+	c.Assert(err, IsNil)
+
+	err = Query.Bind(&comments, newRequest(`GET`, `/?page=0`, ``, ``))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "page")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+}
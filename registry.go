@@ -0,0 +1,168 @@
+package binding
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Registry maps media types onto the bindings that deserialize them. The
+// zero value is an empty registry ready to use.
+type Registry struct {
+	mu       sync.RWMutex
+	bindings map[string]Binding
+}
+
+// DefaultRegistry is used by Bind, Default and BindAll. It holds the
+// built-in bindings for JSON, XML, YAML, MessagePack, Protocol Buffers,
+// CBOR, TOML, CSV, NDJSON, form-urlencoded and multipart payloads.
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := &Registry{}
+	r.Register(MIMEJSON, JSON)
+	r.Register(MIMEXML, XML)
+	r.Register(MIMEXML2, XML)
+	r.Register(MIMEPOSTForm, Form)
+	r.Register(MIMEMultipart, MultipartForm)
+	r.Register(MIMEYAML, YAML)
+	r.Register(MIMEYAML2, YAML)
+	r.Register(MIMEYAML3, YAML)
+	r.Register(MIMEMsgPack, MsgPack)
+	r.Register(MIMEMsgPack2, MsgPack)
+	r.Register(MIMEProtoBuf, ProtoBuf)
+	r.Register(MIMEProtoBuf2, ProtoBuf)
+	r.Register(MIMECBOR, CBOR)
+	r.Register(MIMETOML, TOML)
+	r.Register(MIMECSV, CSV)
+	r.Register(MIMENDJSON, NDJSON)
+	r.Register(MIMEJSONL, NDJSON)
+	return r
+}
+
+// Register binds the requests with mediaType in DefaultRegistry with b.
+func Register(mediaType string, b Binding) {
+	DefaultRegistry.Register(mediaType, b)
+}
+
+// Register binds the requests with mediaType with b. Registering a media
+// type that is already registered, including the built-in ones, replaces
+// its binding. Media types are matched case insensitive and without their
+// parameters.
+func (r *Registry) Register(mediaType string, b Binding) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.bindings == nil {
+		r.bindings = make(map[string]Binding)
+	}
+	r.bindings[normalizeMediaType(mediaType)] = b
+}
+
+// Lookup returns the binding for mediaType. A media type that is not
+// registered itself but has a structured syntax suffix, like
+// application/vnd.api+json, uses the binding of application/json.
+func (r *Registry) Lookup(mediaType string) (Binding, bool) {
+	mediaType = normalizeMediaType(mediaType)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if b, ok := r.bindings[mediaType]; ok {
+		return b, true
+	}
+	if suffix, ok := suffixMediaType(mediaType); ok {
+		b, ok := r.bindings[suffix]
+		return b, ok
+	}
+	return nil, false
+}
+
+// Default returns the binding for the method and content type of a
+// request, falling back to the form binding when the content type is not
+// registered.
+func (r *Registry) Default(method, contentType string) Binding {
+	b, err := r.negotiate(method, contentType)
+	if err != nil {
+		return r.form()
+	}
+	return b
+}
+
+// Bind deserializes the request into obj with the binding for its method
+// and content type.
+func (r *Registry) Bind(obj interface{}, req *http.Request) error {
+	b, err := r.negotiate(req.Method, req.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	return b.Bind(obj, req)
+}
+
+// Selects the binding for a request. Only requests that carry a body, or
+// declare a content type, are bound by their media type. Others are bound
+// from the query string with the form binding.
+func (r *Registry) negotiate(method, contentType string) (Binding, error) {
+	if method != "POST" && method != "PUT" && method != "PATCH" && contentType == "" {
+		return r.form(), nil
+	}
+	if contentType == "" {
+		return nil, ErrorEmptyContentType
+	}
+
+	mediaType, ok := parseMediaType(contentType)
+	if !ok {
+		return nil, ErrorUnsupportedContentType
+	}
+
+	if b, ok := r.Lookup(mediaType); ok {
+		return b, nil
+	}
+	return nil, ErrorUnsupportedContentType
+}
+
+// Returns the binding registered for form-urlencoded payloads, or Form when
+// there is none.
+func (r *Registry) form() Binding {
+	if b, ok := r.Lookup(MIMEPOSTForm); ok {
+		return b
+	}
+	return Form
+}
+
+// Lowercases the media type and strips its parameters.
+func normalizeMediaType(mediaType string) string {
+	if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+		mediaType = mediaType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// Returns the media type of contentType without its parameters. The media
+// type is still returned when only the parameters are malformed.
+func parseMediaType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil && err != mime.ErrInvalidMediaParameter {
+		return "", false
+	}
+	return mediaType, true
+}
+
+// Returns the media type that the structured syntax suffix of mediaType
+// stands for, like application/json for application/vnd.api+json.
+func suffixMediaType(mediaType string) (string, bool) {
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		return "application/" + mediaType[i+1:], true
+	}
+	return "", false
+}
+
+// Determines whether contentType is mediaType or has its structured syntax
+// suffix, as Lookup matches it.
+func hasMediaType(contentType, mediaType string) bool {
+	parsed, ok := parseMediaType(contentType)
+	if !ok {
+		return false
+	}
+	suffix, _ := suffixMediaType(parsed)
+	return parsed == mediaType || suffix == mediaType
+}
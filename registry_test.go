@@ -0,0 +1,134 @@
+package binding
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+// Binds a text/plain body into the title of a Post
+type plainBinding struct{}
+
+func (_ plainBinding) Name() string {
+	return "plain"
+}
+
+func (_ plainBinding) Bind(dst interface{}, req *http.Request) error {
+	post, ok := dst.(*Post)
+	if !ok {
+		return ErrorInputIsNotStructure
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	post.Title = strings.TrimSpace(string(body))
+	return nil
+}
+
+type registrySuite struct{}
+
+var _ = Suite(&registrySuite{})
+
+func (s *registrySuite) Test_ZeroValue(c *C) {
+	r := &Registry{}
+	b, ok := r.Lookup(MIMEJSON)
+
+	c.Assert(ok, Equals, false)
+	c.Assert(b, IsNil)
+	c.Assert(r.Default(`POST`, MIMEJSON), Equals, Form)
+}
+
+func (s *registrySuite) Test_Register(c *C) {
+	r := &Registry{}
+	r.Register(`Text/Plain; charset=utf-8`, plainBinding{})
+
+	post := Post{}
+	err := r.Bind(&post, newRequest(`POST`, ``, `Glorious Post Title`, `text/plain`))
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+}
+
+func (s *registrySuite) Test_UnregisteredMediaType(c *C) {
+	r := &Registry{}
+	r.Register(MIMEPlain, plainBinding{})
+
+	post := Post{}
+	err := r.Bind(&post, newRequest(`POST`, ``, `{}`, MIMEJSON))
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}
+
+func (s *registrySuite) Test_FormFallbackUsesRegistry(c *C) {
+	r := &Registry{}
+	r.Register(MIMEPOSTForm, plainBinding{})
+
+	c.Assert(r.Default(`GET`, ``), Equals, plainBinding{})
+	c.Assert(r.Default(`POST`, MIMEJSON), Equals, plainBinding{})
+
+	post := Post{}
+	err := r.Bind(&post, newRequest(`GET`, `/?title=Query`, `Glorious Post Title`, ``))
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
+
+func (s *registrySuite) Test_StructuredSuffix(c *C) {
+	r := &Registry{}
+	r.Register(MIMEJSON, JSON)
+
+	b, ok := r.Lookup(`application/vnd.api+json`)
+	c.Assert(ok, Equals, true)
+	c.Assert(b, Equals, JSON)
+
+	r.Register(`application/vnd.api+json`, plainBinding{})
+	b, ok = r.Lookup(`application/vnd.api+json`)
+	c.Assert(ok, Equals, true)
+	c.Assert(b, Equals, plainBinding{})
+}
+
+func (s *registrySuite) Test_RegisterOverridesBuiltin(c *C) {
+	defer useDefaultRegistry(newDefaultRegistry())()
+	Register(MIMEJSON, plainBinding{})
+
+	post := Post{}
+	err := Bind(&post, newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: `{"title": "Glorious Post Title"}`})
+	c.Assert(Default(`POST`, MIMEJSON), Equals, plainBinding{})
+}
+
+func (s *registrySuite) Test_RegisterNewFormat(c *C) {
+	defer useDefaultRegistry(newDefaultRegistry())()
+	Register(MIMEPlain, plainBinding{})
+
+	post := Post{}
+	err := Bind(&post, newRequest(`POST`, ``, `Glorious Post Title`, MIMEPlain))
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
+
+func (s *registrySuite) Test_BindAllUsesRegistry(c *C) {
+	defer useDefaultRegistry(newDefaultRegistry())()
+	Register(MIMEPlain, plainBinding{})
+
+	post := Post{}
+	err := BindAll(&post, newRequest(`POST`, ``, `Glorious Post Title`, MIMEPlain))
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
+
+// Replaces DefaultRegistry with r, returning the func that restores it
+func useDefaultRegistry(r *Registry) func() {
+	previous := DefaultRegistry
+	DefaultRegistry = r
+	return func() {
+		DefaultRegistry = previous
+	}
+}
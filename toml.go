@@ -0,0 +1,71 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+
+	"github.com/BurntSushi/toml"
+)
+
+type tomlBinding struct{}
+
+func (_ tomlBinding) Name() string {
+	return "toml"
+}
+
+func (_ tomlBinding) tags() []string {
+	return []string{"toml"}
+}
+
+// TOML deserializes a TOML document from the request into the struct that
+// is passed in, using the toml struct tags, and validates the result.
+func (b tomlBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ tomlBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	if req.Body != nil {
+		defer req.Body.Close()
+		if _, err := toml.NewDecoder(limitedBody(req)).Decode(dst); err != nil {
+			return tomlErrors(err)
+		}
+	}
+	return nil
+}
+
+// Matches the errors of values that do not fit their field, which the
+// decoder only reports as text, like:
+// toml: line 4 (last key "backend.port"): incompatible types: ...
+var tomlTypeErrorPattern = regexp.MustCompile(`^toml: (?:line (\d+) )?\(last key "([^"]*)"\): (.*)$`)
+
+// Translates a decoder error into field errors, keeping the line number
+// reported by the parser. Values that do not fit their field are reported
+// as TypeError for their dotted key.
+func tomlErrors(err error) Errors {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		return deserializationError(fmt.Sprintf("%s (line %d)", parseErr.Message, parseErr.Position.Line), err)
+	}
+
+	if m := tomlTypeErrorPattern.FindStringSubmatch(err.Error()); m != nil {
+		message := m[3]
+		if m[1] != "" {
+			message = fmt.Sprintf("%s (line %s)", m[3], m[1])
+		}
+		return Errors{&FieldError{
+			Field:          m[2],
+			Classification: TypeError,
+			Message:        message,
+			Err:            err,
+		}}
+	}
+	return deserializationError(err.Error(), err)
+}
@@ -0,0 +1,123 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	AdminConfig struct {
+		Name     string     `toml:"name" validate:"Required"`
+		Replicas int        `toml:"replicas" validate:"Range(1,10)"`
+		Backends []Upstream `toml:"backend"`
+	}
+
+	Upstream struct {
+		Host string `toml:"host" validate:"Required"`
+		Port int    `toml:"port"`
+	}
+)
+
+type tomlSuite struct{}
+
+var _ = Suite(&tomlSuite{})
+
+func (s *tomlSuite) Test_NotByReference(c *C) {
+	config := AdminConfig{}
+	err := TOML.Bind(config, newRequest(`POST`, ``, `name = "api"`, MIMETOML))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *tomlSuite) Test_HappyPath(c *C) {
+	payload := "name = \"api\"\nreplicas = 3\n\n[[backend]]\nhost = \"10.0.0.1\"\nport = 8080\n\n[[backend]]\nhost = \"10.0.0.2\"\n"
+
+	config := AdminConfig{}
+	err := TOML.Bind(&config, newRequest(`POST`, ``, payload, MIMETOML))
+
+	c.Assert(err, IsNil)
+	c.Assert(config, DeepEquals, AdminConfig{
+		Name:     "api",
+		Replicas: 3,
+		Backends: []Upstream{{Host: "10.0.0.1", Port: 8080}, {Host: "10.0.0.2"}},
+	})
+}
+
+func (s *tomlSuite) Test_Validation(c *C) {
+	payload := "replicas = 30\n\n[[backend]]\nport = 8080\n"
+
+	config := AdminConfig{}
+	err := TOML.Bind(&config, newRequest(`POST`, ``, payload, MIMETOML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Field, Equals, "name")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+	c.Assert(errs[1].Field, Equals, "replicas")
+	c.Assert(errs[1].Classification, Equals, RangeError)
+	c.Assert(errs[2].Field, Equals, "backend.0.host")
+	c.Assert(errs[2].Classification, Equals, RequiredError)
+}
+
+func (s *tomlSuite) Test_MalformedToml(c *C) {
+	config := AdminConfig{}
+	err := TOML.Bind(&config, newRequest(`POST`, ``, "name = \"api\"\nreplicas = = 3\n", MIMETOML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+	c.Assert(strings.HasSuffix(errs[0].Message, "(line 2)"), Equals, true)
+}
+
+func (s *tomlSuite) Test_TypeMismatch(c *C) {
+	config := AdminConfig{}
+	err := TOML.Bind(&config, newRequest(`POST`, ``, `replicas = "three"`, MIMETOML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "replicas")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(strings.HasSuffix(errs[0].Message, "(line 1)"), Equals, true)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *tomlSuite) Test_NestedTypeMismatch(c *C) {
+	payload := "name = \"api\"\n\n[[backend]]\nhost = \"10.0.0.1\"\nport = \"http\"\n"
+
+	config := AdminConfig{}
+	err := TOML.Bind(&config, newRequest(`POST`, ``, payload, MIMETOML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "backend.port")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(strings.HasSuffix(errs[0].Message, "(line 5)"), Equals, true)
+}
+
+func (s *tomlSuite) Test_MaxBodySize(c *C) {
+	MaxBodySize = 16
+	defer func() { MaxBodySize = int64(1024 * 1024 * 16) }()
+
+	config := AdminConfig{}
+	err := TOML.Bind(&config, newRequest(`POST`, ``, `name = "`+strings.Repeat("a", 32)+`"`, MIMETOML))
+
+	var maxErr *http.MaxBytesError
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.As(err, &maxErr), Equals, true)
+}
+
+func (s *tomlSuite) Test_Bind(c *C) {
+	config := AdminConfig{}
+	err := Bind(&config, newRequest(`POST`, ``, "name = \"api\"\nreplicas = 1\n", `application/toml; charset=utf-8`))
+
+	c.Assert(err, IsNil)
+	c.Assert(config, DeepEquals, AdminConfig{Name: "api", Replicas: 1})
+	c.Assert(Default(`POST`, MIMETOML), Equals, TOML)
+}
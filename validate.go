@@ -0,0 +1,545 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Classifications of validation errors.
+const (
+	ValidationError   = "ValidationError"
+	RequiredError     = "RequiredError"
+	AlphaDashError    = "AlphaDashError"
+	AlphaDashDotError = "AlphaDashDotError"
+	MinSizeError      = "MinSizeError"
+	MaxSizeError      = "MaxSizeError"
+	EmailError        = "EmailError"
+	UrlError          = "UrlError"
+	RangeError        = "RangeError"
+	InError           = "InError"
+	NotInError        = "NotInError"
+	IncludeError      = "IncludeError"
+	ExcludeError      = "ExcludeError"
+	DefaultError      = "DefaultError"
+)
+
+var (
+	alphaDashPattern    = regexp.MustCompile("[^\\d\\w-_]")
+	alphaDashDotPattern = regexp.MustCompile("[^\\d\\w-_\\.]")
+	emailPattern        = regexp.MustCompile("[\\w!#$%&'*+/=?^_`{|}~-]+(?:\\.[\\w!#$%&'*+/=?^_`{|}~-]+)*@(?:[\\w](?:[\\w-]*[\\w])?\\.)+[a-zA-Z0-9](?:[\\w-]*[\\w])?")
+	urlPattern          = regexp.MustCompile(`(http|https):\/\/[\w\-_]+(\.[\w\-_]+)+([\w\-\.,@?^=%&amp;:/~\+#]*[\w\-\@?^=%&amp;/~\+#])?`)
+)
+
+// Validator can be implemented by the bound struct, or any struct nested in
+// it, to perform checks that involve multiple fields. Validate is called
+// after the validate tags of the struct have been checked.
+type Validator interface {
+	Validate() error
+}
+
+// RequestValidator is the variant of Validator that receives the request
+// the struct was bound from.
+type RequestValidator interface {
+	Validate(*http.Request) Errors
+}
+
+// Performs the rules of the validate tags on obj and everything nested in it.
+// Field paths are built from the tag used by the binding (form, json, xml),
+// the first of tags present on a field, and fields that already have an
+// error in errs are not validated again.
+func validate(errs *Errors, obj reflect.Value, req *http.Request, tags ...string) {
+	validateValue(errs, obj, "", req, tags)
+}
+
+func validateValue(errs *Errors, val reflect.Value, path string, req *http.Request, tags []string) {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		validateStruct(errs, val, path, req, tags)
+		callValidator(errs, val, path, req)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			validateValue(errs, val.Index(i), path+strconv.Itoa(i)+".", req, tags)
+		}
+	}
+}
+
+// Performs required field checking on a struct
+func validateStruct(errs *Errors, val reflect.Value, path string, req *http.Request, tags []string) {
+	typ := val.Type()
+	checkRules(typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+
+		// Allow ignored fields in the struct
+		name := fieldName(field, tags...)
+		if name == "-" || !fieldVal.CanInterface() {
+			continue
+		}
+
+		// Validate nested and embedded structs (if pointer, only do so if not nil)
+		// and structure slices
+		if field.Anonymous && isValidator(typ) {
+			validateEmbedded(errs, fieldVal, path, req, tags)
+		} else if field.Anonymous {
+			validateValue(errs, fieldVal, path, req, tags)
+		} else if isStructOrSliceOfStructs(field.Type) {
+			validateValue(errs, fieldVal, path+name+".", req, tags)
+		}
+
+		if errs.hasField(path + name) {
+			continue
+		}
+		applyRules(errs, path+name, field, fieldVal)
+	}
+}
+
+// Performs the rules of the validate tags of the fields of obj that carry
+// tag, including those of embedded structs. Used by the bindings that only
+// fill part of a struct, like Path, so the fields bound from other parts of
+// the request are left to their own binding. Validator implementations are
+// not called, as they may depend on those other fields.
+func validateSource(errs *Errors, obj reflect.Value, tag string) {
+	for obj.Kind() == reflect.Ptr {
+		if obj.IsNil() {
+			return
+		}
+		obj = obj.Elem()
+	}
+	if obj.Kind() != reflect.Struct {
+		return
+	}
+
+	typ := obj.Type()
+	checkRules(typ)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := obj.Field(i)
+		if !fieldVal.CanInterface() {
+			continue
+		}
+
+		if field.Anonymous {
+			validateSource(errs, fieldVal, tag)
+			continue
+		}
+
+		name := field.Tag.Get(tag)
+		if name == "" || name == "-" || errs.hasField(name) {
+			continue
+		}
+		applyRules(errs, name, field, fieldVal)
+	}
+}
+
+// Applies the rules of the validate tag of the field until one fails
+func applyRules(errs *Errors, path string, field reflect.StructField, fieldVal reflect.Value) {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ";") {
+		if len(rule) == 0 {
+			continue
+		}
+
+		if fieldErr := validateRule(rule, path, field, fieldVal); fieldErr != nil {
+			*errs = append(*errs, fieldErr)
+			return
+		}
+	}
+}
+
+// Validates an embedded struct without calling its Validator, which the
+// outer struct already promotes or overrides.
+func validateEmbedded(errs *Errors, val reflect.Value, path string, req *http.Request, tags []string) {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+	if val.Kind() == reflect.Struct {
+		validateStruct(errs, val, path, req, tags)
+	}
+}
+
+var (
+	validatorType        = reflect.TypeOf((*Validator)(nil)).Elem()
+	requestValidatorType = reflect.TypeOf((*RequestValidator)(nil)).Elem()
+)
+
+// Determines whether a pointer to typ implements Validator or
+// RequestValidator, either itself or through an embedded struct.
+func isValidator(typ reflect.Type) bool {
+	ptr := reflect.PtrTo(typ)
+	return ptr.Implements(validatorType) || ptr.Implements(requestValidatorType)
+}
+
+// Calls the Validator or RequestValidator implementation of the struct and
+// merges the returned errors, prefixing their fields with the struct path.
+func callValidator(errs *Errors, val reflect.Value, path string, req *http.Request) {
+	obj := val.Interface()
+	if val.CanAddr() {
+		obj = val.Addr().Interface()
+	}
+
+	var err error
+	switch v := obj.(type) {
+	case Validator:
+		err = v.Validate()
+	case RequestValidator:
+		err = v.Validate(req).errorOrNil()
+	}
+	if err == nil {
+		return
+	}
+
+	switch e := err.(type) {
+	case Errors:
+		for _, fieldErr := range e {
+			*errs = append(*errs, validationError(prefixFieldError(fieldErr, path)))
+		}
+	case *FieldError:
+		*errs = append(*errs, validationError(prefixFieldError(e, path)))
+	default:
+		*errs = append(*errs, validationError(&FieldError{
+			Field:          strings.TrimSuffix(path, "."),
+			Classification: ValidationError,
+			Message:        err.Error(),
+			Err:            err,
+		}))
+	}
+}
+
+// Marks the error as a validation failure, matching ErrorValidation
+func validationError(fieldErr *FieldError) *FieldError {
+	fieldErr.validation = true
+	return fieldErr
+}
+
+// Returns a copy of the error with the field relative to the struct path
+func prefixFieldError(fieldErr *FieldError, path string) *FieldError {
+	prefixed := *fieldErr
+	if prefixed.Field == "" {
+		prefixed.Field = strings.TrimSuffix(path, ".")
+	} else {
+		prefixed.Field = path + prefixed.Field
+	}
+	if prefixed.Classification == "" {
+		prefixed.Classification = ValidationError
+	}
+	return &prefixed
+}
+
+// RuleContext holds the field a validation rule is applied to.
+type RuleContext struct {
+	// Path of the field, like reviewers.1.name
+	Field string
+
+	// Name of the rule and the comma separated arguments between its
+	// parentheses; Currency(EUR,USD) has the arguments EUR and USD.
+	Name string
+	Args []string
+
+	// The struct field and its value. The value can be set when the bound
+	// model was passed by reference.
+	StructField reflect.StructField
+	Value       reflect.Value
+}
+
+// RuleFunc validates a single field. A non nil error marks the field as
+// invalid. The error is reported with the classification of the rule name
+// suffixed by Error (e.g. IBANError), unless a *FieldError is returned.
+type RuleFunc func(ctx RuleContext) error
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]RuleFunc{
+		"Required":     ruleRequired,
+		"AlphaDash":    ruleAlphaDash,
+		"AlphaDashDot": ruleAlphaDashDot,
+		"MinSize":      ruleMinSize,
+		"MaxSize":      ruleMaxSize,
+		"Email":        ruleEmail,
+		"Url":          ruleUrl,
+		"Range":        ruleRange,
+		"In":           ruleIn,
+		"NotIn":        ruleNotIn,
+		"Include":      ruleInclude,
+		"Exclude":      ruleExclude,
+		"Default":      ruleDefault,
+	}
+)
+
+// RegisterRule makes a validation rule available under name for use in
+// validate tags. Registering a rule with the name of an existing rule,
+// including the built-in ones, replaces it.
+func RegisterRule(name string, fn RuleFunc) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = fn
+}
+
+func lookupRule(name string) (RuleFunc, bool) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	fn, ok := rules[name]
+	return fn, ok
+}
+
+// Applies a single rule to the field value, returning the failure or nil
+// when it passes. The rules have been checked to exist by checkRules.
+func validateRule(rule string, path string, field reflect.StructField, fieldVal reflect.Value) *FieldError {
+	name, args := parseRule(rule)
+
+	// Fields without a value are only subject to Required and Default, but
+	// zero numbers and booleans are values to Range, In and NotIn
+	if fieldVal.IsZero() && name != "Required" && name != "Default" && !checksZero(name, fieldVal) {
+		return nil
+	}
+
+	fn, ok := lookupRule(name)
+	if !ok {
+		return nil
+	}
+
+	err := fn(RuleContext{
+		Field:       path,
+		Name:        name,
+		Args:        args,
+		StructField: field,
+		Value:       fieldVal,
+	})
+	if err == nil {
+		return nil
+	}
+
+	if fieldErr, ok := err.(*FieldError); ok {
+		ruleErr := *fieldErr
+		ruleErr.Field = path
+		if ruleErr.Classification == "" {
+			ruleErr.Classification = name + "Error"
+		}
+		return validationError(&ruleErr)
+	}
+	return validationError(&FieldError{
+		Field:          path,
+		Classification: name + "Error",
+		Message:        err.Error(),
+		Err:            err,
+	})
+}
+
+// Determines whether the rule checks the zero value of fieldVal, which is
+// a value of its own for numbers and booleans.
+func checksZero(rule string, fieldVal reflect.Value) bool {
+	if rule != "Range" && rule != "In" && rule != "NotIn" {
+		return false
+	}
+	switch fieldVal.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Struct types of which the validate tags only use known rules
+var checkedRules sync.Map
+
+// Panics when a validate tag of typ names a rule that is not registered,
+// so a misspelled rule like Requird does not silently disable validation.
+// Rules must therefore be registered before a struct using them is bound.
+func checkRules(typ reflect.Type) {
+	if _, ok := checkedRules.Load(typ); ok {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ";") {
+			if len(rule) == 0 {
+				continue
+			}
+			if name, _ := parseRule(rule); !ruleExists(name) {
+				panic(fmt.Sprintf("binding: unknown validation rule %q on field %s.%s", name, typ, field.Name))
+			}
+		}
+	}
+	checkedRules.Store(typ, true)
+}
+
+func ruleExists(name string) bool {
+	_, ok := lookupRule(name)
+	return ok
+}
+
+// Splits a rule like Range(1,10) into its name and arguments
+func parseRule(rule string) (string, []string) {
+	start := strings.Index(rule, "(")
+	if start == -1 || !strings.HasSuffix(rule, ")") {
+		return rule, nil
+	}
+	return rule[:start], strings.Split(rule[start+1:len(rule)-1], ",")
+}
+
+func ruleRequired(ctx RuleContext) error {
+	if ctx.Value.IsZero() {
+		return errors.New("is required")
+	}
+	return nil
+}
+
+func ruleAlphaDash(ctx RuleContext) error {
+	if alphaDashPattern.MatchString(valueString(ctx.Value)) {
+		return errors.New("must contain only letters, digits, dashes and underscores")
+	}
+	return nil
+}
+
+func ruleAlphaDashDot(ctx RuleContext) error {
+	if alphaDashDotPattern.MatchString(valueString(ctx.Value)) {
+		return errors.New("must contain only letters, digits, dashes, underscores and dots")
+	}
+	return nil
+}
+
+func ruleMinSize(ctx RuleContext) error {
+	min, _ := strconv.Atoi(strings.Join(ctx.Args, ","))
+	if size, ok := valueSize(ctx.Value); ok && size < min {
+		return fmt.Errorf("must have a minimum size of %d", min)
+	}
+	return nil
+}
+
+func ruleMaxSize(ctx RuleContext) error {
+	max, _ := strconv.Atoi(strings.Join(ctx.Args, ","))
+	if size, ok := valueSize(ctx.Value); ok && size > max {
+		return fmt.Errorf("must have a maximum size of %d", max)
+	}
+	return nil
+}
+
+func ruleEmail(ctx RuleContext) error {
+	if !emailPattern.MatchString(valueString(ctx.Value)) {
+		return errors.New("must be a valid email address")
+	}
+	return nil
+}
+
+func ruleUrl(ctx RuleContext) error {
+	if !urlPattern.MatchString(valueString(ctx.Value)) {
+		return errors.New("must be a valid url")
+	}
+	return nil
+}
+
+func ruleRange(ctx RuleContext) error {
+	if len(ctx.Args) != 2 {
+		return nil
+	}
+	val, _ := strconv.ParseFloat(valueString(ctx.Value), 64)
+	a, _ := strconv.ParseFloat(ctx.Args[0], 64)
+	b, _ := strconv.ParseFloat(ctx.Args[1], 64)
+	if val < a || val > b {
+		return fmt.Errorf("must be between %s and %s", ctx.Args[0], ctx.Args[1])
+	}
+	return nil
+}
+
+func ruleIn(ctx RuleContext) error {
+	if !in(valueString(ctx.Value), ctx.Args) {
+		return fmt.Errorf("must be one of %s", strings.Join(ctx.Args, ","))
+	}
+	return nil
+}
+
+func ruleNotIn(ctx RuleContext) error {
+	if in(valueString(ctx.Value), ctx.Args) {
+		return fmt.Errorf("must not be one of %s", strings.Join(ctx.Args, ","))
+	}
+	return nil
+}
+
+func ruleInclude(ctx RuleContext) error {
+	substr := strings.Join(ctx.Args, ",")
+	if !strings.Contains(valueString(ctx.Value), substr) {
+		return fmt.Errorf("must include %q", substr)
+	}
+	return nil
+}
+
+func ruleExclude(ctx RuleContext) error {
+	substr := strings.Join(ctx.Args, ",")
+	if strings.Contains(valueString(ctx.Value), substr) {
+		return fmt.Errorf("must not include %q", substr)
+	}
+	return nil
+}
+
+func ruleDefault(ctx RuleContext) error {
+	if !ctx.Value.IsZero() {
+		return nil
+	}
+	if !ctx.Value.CanSet() || setWithProperType(ctx.Value.Kind(), strings.Join(ctx.Args, ","), ctx.Value) != nil {
+		return errors.New("cannot set default value")
+	}
+	return nil
+}
+
+func valueString(val reflect.Value) string {
+	return fmt.Sprintf("%v", val.Interface())
+}
+
+// Returns the length of strings (in runes), slices, arrays and maps
+func valueSize(val reflect.Value) (int, bool) {
+	switch val.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(val.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return val.Len(), true
+	}
+	return 0, false
+}
+
+// Determines whether val is one of the values in arr
+func in(val string, arr []string) bool {
+	for _, v := range arr {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the name of the field as used in the first of the binding tags
+// present, or the lowercased field name when none of them is.
+func fieldName(field reflect.StructField, tags ...string) string {
+	for _, tag := range tags {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name)
+}
+
+func isStructOrSliceOfStructs(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
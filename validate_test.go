@@ -0,0 +1,314 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	Reviewer struct {
+		Name  string `form:"name" json:"name" validate:"Required;MaxSize(10)"`
+		Email string `form:"email" json:"email" validate:"Email"`
+	}
+
+	Book struct {
+		Title     string      `form:"title" json:"title" validate:"Required;AlphaDashDot"`
+		Slug      string      `form:"slug" json:"slug" validate:"AlphaDash"`
+		Pages     int         `form:"pages" json:"pages" validate:"Range(1,1000)"`
+		Genre     string      `form:"genre" json:"genre" validate:"In(fantasy,thriller)"`
+		Format    string      `form:"format" json:"format" validate:"NotIn(scroll)"`
+		Isbn      string      `form:"isbn" json:"isbn" validate:"Include(-);Exclude( );MinSize(10)"`
+		Website   string      `form:"website" json:"website" validate:"Url"`
+		Language  string      `form:"language" json:"language" validate:"Default(en)"`
+		Editions  int         `form:"editions" json:"editions" validate:"Default(1)"`
+		Author    Reviewer    `form:"author" json:"author"`
+		Editor    *Reviewer   `form:"editor" json:"editor"`
+		Reviewers []*Reviewer `form:"reviewers" json:"reviewers"`
+	}
+)
+
+type validateSuite struct{}
+
+var _ = Suite(&validateSuite{})
+
+func (s *validateSuite) Test_Valid(c *C) {
+	book := Book{}
+	req := newRequest(`POST`, ``, `title=Go.Binding&slug=go-binding&pages=42&genre=fantasy&format=paper&isbn=978-0-13-468599-1&website=http://example.com&author.name=Matt`, formContentType)
+	err := Form.Bind(&book, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(book.Language, Equals, "en")
+	c.Assert(book.Editions, Equals, 1)
+}
+
+func (s *validateSuite) Test_Invalid(c *C) {
+	book := Book{}
+	req := newRequest(`POST`, ``, `title=Go+Binding&slug=go.binding&pages=2000&genre=romance&format=scroll&isbn=978+0&website=ftp://example&author.email=matt&reviewers.1.name=AVeryLongName`, formContentType)
+	err := Form.Bind(&book, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errors.Is(err, ErrorValidation), Equals, true)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, false)
+
+	fields := map[string]string{}
+	for _, fieldErr := range errs {
+		fields[fieldErr.Field] = fieldErr.Classification
+	}
+	c.Assert(fields, DeepEquals, map[string]string{
+		"title":            AlphaDashDotError,
+		"slug":             AlphaDashError,
+		"pages":            RangeError,
+		"genre":            InError,
+		"format":           NotInError,
+		"isbn":             IncludeError,
+		"website":          UrlError,
+		"author.name":      RequiredError,
+		"author.email":     EmailError,
+		"reviewers.0.name": RequiredError,
+		"reviewers.1.name": MaxSizeError,
+	})
+}
+
+func (s *validateSuite) Test_NilPointerStructNotValidated(c *C) {
+	book := Book{}
+	req := newRequest(`POST`, ``, `title=Binding&pages=42&author.name=Matt`, formContentType)
+	err := Form.Bind(&book, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(book.Editor, IsNil)
+}
+
+func (s *validateSuite) Test_SkipFieldsWithConversionError(c *C) {
+	StrictConversion = true
+	defer func() { StrictConversion = false }()
+
+	book := Book{}
+	req := newRequest(`POST`, ``, `title=Binding&author.name=Matt&pages=many`, formContentType)
+	err := Form.Bind(&book, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "pages")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+}
+
+func (s *validateSuite) Test_Json(c *C) {
+	books := []Book{}
+	req := newRequest(`POST`, ``, `[{"title":"Binding","pages":42,"author":{"name":"Matt"}},{"title":"Binding","pages":42,"author":{"email":"matt"},"editor":{"name":"Ed"}}]`, jsonContentType)
+	err := JSON.Bind(&books, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "1.author.name")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+	c.Assert(errs[1].Field, Equals, "1.author.email")
+	c.Assert(errs[1].Classification, Equals, EmailError)
+	c.Assert(books[0].Language, Equals, "en")
+}
+
+type OrderLine struct {
+	Quantity int  `form:"quantity" json:"quantity" validate:"Range(1,100)"`
+	Discount int  `form:"discount" json:"discount" validate:"In(0,10,20)"`
+	Priority int  `form:"priority" json:"priority" validate:"NotIn(0)"`
+	Gift     bool `form:"gift" json:"gift" validate:"In(true)"`
+}
+
+func (s *validateSuite) Test_ZeroNumbersAreValues(c *C) {
+	for _, req := range []*http.Request{
+		newRequest(`POST`, ``, `quantity=0&discount=0&priority=0&gift=false`, formContentType),
+		newRequest(`POST`, ``, `{"quantity":0,"discount":0,"priority":0,"gift":false}`, jsonContentType),
+	} {
+		line := OrderLine{}
+		err := Bind(&line, req)
+
+		var errs Errors
+		c.Assert(errors.As(err, &errs), Equals, true)
+		c.Assert(errs, HasLen, 3)
+		c.Assert(errs[0].Field, Equals, "quantity")
+		c.Assert(errs[0].Classification, Equals, RangeError)
+		c.Assert(errs[1].Field, Equals, "priority")
+		c.Assert(errs[1].Classification, Equals, NotInError)
+		c.Assert(errs[2].Field, Equals, "gift")
+		c.Assert(errs[2].Classification, Equals, InError)
+	}
+}
+
+type Payment struct {
+	Currency string `form:"currency" validate:"Currency(EUR,USD)"`
+	Amount   int    `form:"amount" validate:"Positive"`
+}
+
+type Misspelled struct {
+	Name string `form:"name" validate:"Requird"`
+}
+
+func (s *validateSuite) Test_RegisterRule(c *C) {
+	RegisterRule("Currency", func(ctx RuleContext) error {
+		c.Assert(ctx.Name, Equals, "Currency")
+		c.Assert(ctx.Args, DeepEquals, []string{"EUR", "USD"})
+		c.Assert(ctx.Field, Equals, "currency")
+		if !in(ctx.Value.String(), ctx.Args) {
+			return errors.New("unsupported currency")
+		}
+		return nil
+	})
+	RegisterRule("Positive", func(ctx RuleContext) error {
+		if ctx.Value.Int() < 0 {
+			return &FieldError{Classification: "SignError", Message: "must be positive"}
+		}
+		return nil
+	})
+
+	payment := Payment{}
+	req := newRequest(`POST`, ``, `currency=GBP&amount=-5&note=foo`, formContentType)
+	err := Form.Bind(&payment, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "currency")
+	c.Assert(errs[0].Classification, Equals, "CurrencyError")
+	c.Assert(errs[0].Message, Equals, "unsupported currency")
+	c.Assert(errors.Is(errs[0], ErrorValidation), Equals, true)
+	c.Assert(errs[1].Field, Equals, "amount")
+	c.Assert(errs[1].Classification, Equals, "SignError")
+	c.Assert(errs[1].Message, Equals, "must be positive")
+	c.Assert(errors.Is(errs[1], ErrorValidation), Equals, true)
+
+	payment = Payment{}
+	req = newRequest(`POST`, ``, `currency=EUR&amount=5`, formContentType)
+	c.Assert(Form.Bind(&payment, req), IsNil)
+}
+
+func (s *validateSuite) Test_UnknownRulePanics(c *C) {
+	misspelled := Misspelled{}
+	req := newRequest(`POST`, ``, `name=Matt`, formContentType)
+
+	c.Assert(func() { Form.Bind(&misspelled, req) }, PanicMatches, `binding: unknown validation rule "Requird" on field binding.Misspelled.Name`)
+}
+
+type (
+	Period struct {
+		Start int `form:"start" json:"start"`
+		End   int `form:"end" json:"end"`
+	}
+
+	Booking struct {
+		Room    string   `form:"room" json:"room"`
+		Periods []Period `form:"periods" json:"periods"`
+	}
+)
+
+func (p Period) Validate() error {
+	if p.End < p.Start {
+		return Errors{&FieldError{Field: "end", Classification: "PeriodError", Message: "must be after start"}}
+	}
+	return nil
+}
+
+func (b *Booking) Validate(req *http.Request) Errors {
+	errs := Errors{}
+	if b.Room == "" && req.URL.Query().Get("room") == "" {
+		errs.Add("room", RequiredError, "is required")
+	}
+	if len(b.Periods) == 0 {
+		errs.Add("", ValidationError, "no periods booked")
+	}
+	return errs
+}
+
+func (s *validateSuite) Test_Validator(c *C) {
+	booking := Booking{}
+	req := newRequest(`POST`, ``, `periods.0.start=1&periods.0.end=2&periods.1.start=5&periods.1.end=3`, formContentType)
+	err := Form.Bind(&booking, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Field, Equals, "periods.1.end")
+	c.Assert(errs[0].Classification, Equals, "PeriodError")
+	c.Assert(errors.Is(errs[0], ErrorValidation), Equals, true)
+	c.Assert(errs[1].Field, Equals, "room")
+	c.Assert(errs[1].Classification, Equals, RequiredError)
+	c.Assert(errors.Is(err, ErrorValidation), Equals, true)
+}
+
+func (s *validateSuite) Test_ValidatorJson(c *C) {
+	booking := &Booking{}
+	req := newRequest(`POST`, `?room=12`, `{"periods":[]}`, jsonContentType)
+	err := JSON.Bind(&booking, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "")
+	c.Assert(errs[0].Message, Equals, "no periods booked")
+	c.Assert(errors.Is(err, ErrorValidation), Equals, true)
+}
+
+func (s *validateSuite) Test_ValidatorSliceOfStructs(c *C) {
+	periods := []Period{}
+	req := newRequest(`POST`, ``, `[{"start":1,"end":2},{"start":3,"end":1}]`, jsonContentType)
+	err := JSON.Bind(&periods, req)
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "1.end")
+}
+
+type Passwords struct {
+	Password string `form:"password"`
+	Confirm  string `form:"confirm"`
+}
+
+func (p *Passwords) Validate() error {
+	if p.Password != p.Confirm {
+		return errors.New("passwords do not match")
+	}
+	return nil
+}
+
+func (s *validateSuite) Test_ValidatorPlainError(c *C) {
+	passwords := Passwords{}
+	req := newRequest(`POST`, ``, `password=foo&confirm=bar`, formContentType)
+	err := Form.Bind(&passwords, req)
+
+	var fieldErr *FieldError
+	c.Assert(errors.As(err, &fieldErr), Equals, true)
+	c.Assert(fieldErr.Field, Equals, "")
+	c.Assert(fieldErr.Classification, Equals, ValidationError)
+	c.Assert(fieldErr.Message, Equals, "passwords do not match")
+	c.Assert(errors.Is(err, ErrorValidation), Equals, true)
+}
+
+type (
+	Audited struct {
+		calls *int
+	}
+
+	AuditedBooking struct {
+		Audited
+		Room string `json:"room"`
+	}
+)
+
+func (a Audited) Validate() error {
+	*a.calls++
+	return nil
+}
+
+func (s *validateSuite) Test_PromotedValidatorRunsOnce(c *C) {
+	calls := 0
+	booking := AuditedBooking{Audited: Audited{calls: &calls}}
+	req := newRequest(`POST`, ``, `{"room":"101"}`, jsonContentType)
+	err := JSON.Bind(&booking, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(calls, Equals, 1)
+}
@@ -2,6 +2,7 @@ package binding
 
 import (
 	"encoding/xml"
+	"fmt"
 	"io"
 	"net/http"
 	"reflect"
@@ -13,7 +14,15 @@ func (_ xmlBinding) Name() string {
 	return "xml"
 }
 
-func (_ xmlBinding) Bind(dst interface{}, req *http.Request) error {
+func (_ xmlBinding) tags() []string {
+	return []string{"xml"}
+}
+
+func (b xmlBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ xmlBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return ErrorInputNotByReference
@@ -21,10 +30,19 @@ func (_ xmlBinding) Bind(dst interface{}, req *http.Request) error {
 
 	if req.Body != nil {
 		defer req.Body.Close()
-		err := xml.NewDecoder(req.Body).Decode(dst)
+		err := xml.NewDecoder(limitedBody(req)).Decode(dst)
 		if err != nil && err != io.EOF {
-			return ErrorDeserialization
+			return xmlErrors(err)
 		}
 	}
 	return nil
 }
+
+// Translates a decoder error into field errors, keeping the line number
+// reported by encoding/xml.
+func xmlErrors(err error) Errors {
+	if e, ok := err.(*xml.SyntaxError); ok {
+		return deserializationError(fmt.Sprintf("%s (line %d)", e.Msg, e.Line), err)
+	}
+	return deserializationError(err.Error(), err)
+}
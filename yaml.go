@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlBinding struct{}
+
+func (_ yamlBinding) Name() string {
+	return "yaml"
+}
+
+func (_ yamlBinding) tags() []string {
+	return []string{"yaml"}
+}
+
+// YAML deserializes a YAML payload from the request into the struct that
+// is passed in, using the yaml struct tags, and validates the result.
+func (b yamlBinding) Bind(dst interface{}, req *http.Request) error {
+	return bindValidated(b, dst, req)
+}
+
+func (_ yamlBinding) bind(dst interface{}, req *http.Request, errs *Errors) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	if req.Body != nil {
+		defer req.Body.Close()
+		data, err := io.ReadAll(limitedBody(req))
+		if err != nil {
+			return deserializationError(err.Error(), err)
+		}
+		if err := yaml.Unmarshal(data, dst); err != nil {
+			return yamlErrors(data, err)
+		}
+	}
+	return nil
+}
+
+var yamlLinePattern = regexp.MustCompile(`^line (\d+): `)
+
+// Translates a decoder error into field errors. The decoder reports every
+// value it could not convert, each prefixed with its line number, which is
+// looked up in the document to name the field.
+func yamlErrors(data []byte, err error) Errors {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return deserializationError(err.Error(), err)
+	}
+
+	var doc yaml.Node
+	fields := map[int]string{}
+	if yaml.Unmarshal(data, &doc) == nil {
+		yamlFields(&doc, "", fields)
+	}
+
+	errs := Errors{}
+	for _, msg := range typeErr.Errors {
+		fe := &FieldError{
+			Classification: TypeError,
+			Message:        msg,
+			Err:            err,
+		}
+		if m := yamlLinePattern.FindStringSubmatch(msg); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			fe.Field = fields[line]
+		}
+		errs = append(errs, fe)
+	}
+	return errs
+}
+
+// Maps the lines of the values in node onto their field path, like
+// stages.0.retries, keeping the outermost value of each line.
+func yamlFields(node *yaml.Node, path string, fields map[int]string) {
+	if _, ok := fields[node.Line]; !ok && path != "" {
+		fields[node.Line] = path
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			yamlFields(child, path, fields)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			yamlFields(node.Content[i+1], joinPath(path, node.Content[i].Value), fields)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			yamlFields(child, joinPath(path, strconv.Itoa(i)), fields)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type (
+	Pipeline struct {
+		Name   string  `yaml:"name" validate:"Required"`
+		Stages []Stage `yaml:"stages"`
+	}
+
+	Stage struct {
+		Name    string `yaml:"name" validate:"Required"`
+		Retries int    `yaml:"retries"`
+	}
+)
+
+type yamlSuite struct{}
+
+var _ = Suite(&yamlSuite{})
+
+func (s *yamlSuite) Test_NotByReference(c *C) {
+	pipeline := Pipeline{}
+	err := YAML.Bind(pipeline, newRequest(`POST`, ``, `name: build`, MIMEYAML))
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *yamlSuite) Test_HappyPath(c *C) {
+	pipeline := Pipeline{}
+	req := newRequest(`POST`, ``, "name: build\nstages:\n  - name: test\n    retries: 2\n  - name: deploy\n", MIMEYAML)
+	err := YAML.Bind(&pipeline, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(pipeline, DeepEquals, Pipeline{Name: "build", Stages: []Stage{{Name: "test", Retries: 2}, {Name: "deploy"}}})
+}
+
+func (s *yamlSuite) Test_EmptyPayload(c *C) {
+	pipeline := Pipeline{}
+	err := YAML.Bind(&pipeline, newRequest(`POST`, ``, ``, MIMEYAML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "name")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+}
+
+func (s *yamlSuite) Test_ValidationUsesYamlTags(c *C) {
+	pipeline := Pipeline{}
+	err := YAML.Bind(&pipeline, newRequest(`POST`, ``, "name: build\nstages:\n  - retries: 1\n", MIMEYAML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "stages.0.name")
+	c.Assert(errs[0].Classification, Equals, RequiredError)
+}
+
+func (s *yamlSuite) Test_TypeMismatch(c *C) {
+	pipeline := Pipeline{}
+	err := YAML.Bind(&pipeline, newRequest(`POST`, ``, "name: build\nstages:\n  - name: test\n    retries: often\n", MIMEYAML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "stages.0.retries")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+	c.Assert(strings.HasPrefix(errs[0].Message, "line 4:"), Equals, true)
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *yamlSuite) Test_TypeMismatchOfCollection(c *C) {
+	pipeline := Pipeline{}
+	err := YAML.Bind(&pipeline, newRequest(`POST`, ``, "name:\n  - build\n  - test\nstages: []\n", MIMEYAML))
+
+	var errs Errors
+	c.Assert(errors.As(err, &errs), Equals, true)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Field, Equals, "name")
+	c.Assert(errs[0].Classification, Equals, TypeError)
+}
+
+func (s *yamlSuite) Test_MaxBodySize(c *C) {
+	MaxBodySize = 16
+	defer func() { MaxBodySize = int64(1024 * 1024 * 16) }()
+
+	pipeline := Pipeline{}
+	err := YAML.Bind(&pipeline, newRequest(`POST`, ``, "name: "+strings.Repeat("b", 32), MIMEYAML))
+
+	var maxErr *http.MaxBytesError
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+	c.Assert(errors.As(err, &maxErr), Equals, true)
+}
+
+func (s *yamlSuite) Test_MalformedYaml(c *C) {
+	pipeline := Pipeline{}
+	err := YAML.Bind(&pipeline, newRequest(`POST`, ``, "name: [build", MIMEYAML))
+
+	c.Assert(errors.Is(err, ErrorDeserialization), Equals, true)
+}
+
+func (s *yamlSuite) Test_Bind(c *C) {
+	for _, contentType := range []string{MIMEYAML, MIMEYAML2, MIMEYAML3, "application/vnd.ci+yaml"} {
+		pipeline := Pipeline{}
+		err := Bind(&pipeline, newRequest(`POST`, ``, `name: build`, contentType))
+
+		c.Assert(err, IsNil, Commentf(contentType))
+		c.Assert(pipeline.Name, Equals, "build")
+	}
+}